@@ -1,8 +1,26 @@
 package app
 
 import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/parvez3019/go-swagger3/docs"
+	"github.com/parvez3019/go-swagger3/generator"
+	"github.com/parvez3019/go-swagger3/merger"
+	"github.com/parvez3019/go-swagger3/mock"
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
 	parserPkg "github.com/parvez3019/go-swagger3/parser"
+	"github.com/parvez3019/go-swagger3/parser/utils"
+	"github.com/parvez3019/go-swagger3/postman"
+	"github.com/parvez3019/go-swagger3/reader"
+	"github.com/parvez3019/go-swagger3/transform"
 	"github.com/parvez3019/go-swagger3/writer"
+	log "github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
 )
 
@@ -22,14 +40,347 @@ func NewApp() *App {
 	}
 	cliApp.Flags = flags
 	cliApp.Action = action
+	cliApp.Commands = commands
 
 	return &App{
 		App: cliApp,
 	}
 }
 
+var commands = []cli.Command{
+	{
+		Name:   "mock",
+		Usage:  "serve mock responses for the documented operations",
+		Flags:  mockFlags,
+		Action: mockAction,
+	},
+	{
+		Name:   "client",
+		Usage:  "generate a go client for the documented operations",
+		Flags:  clientFlags,
+		Action: clientAction,
+	},
+	{
+		Name:   "typescript",
+		Usage:  "generate typescript types for the schemas and operations",
+		Flags:  typescriptFlags,
+		Action: typescriptAction,
+	},
+	{
+		Name:   "server",
+		Usage:  "generate a go server interface and router for the documented operations",
+		Flags:  serverFlags,
+		Action: serverAction,
+	},
+	{
+		Name:      "import",
+		Usage:     "generate the annotated go code of an existing spec",
+		ArgsUsage: "<spec file>",
+		Flags:     importFlags,
+		Action:    importAction,
+	},
+	{
+		Name:      "bundle",
+		Usage:     "merge a spec written with --output-dir back into the --output file",
+		ArgsUsage: "<root file>",
+		Action:    bundleAction,
+	},
+	{
+		Name:      "merge",
+		Usage:     "merge the specs of several services into the --output file",
+		ArgsUsage: "[service=]<spec file>...",
+		Flags:     mergeFlags,
+		Action:    mergeAction,
+	},
+}
+
 func action(c *cli.Context) error {
 	args := LoadArgs(c)
+	openApiObject, err := parse(args)
+	if err != nil {
+		return err
+	}
+	if args.pruneUnused {
+		if err := prune(&openApiObject, args); err != nil {
+			return err
+		}
+	}
+	if args.dereference {
+		dereferenced, err := transform.Dereference(&openApiObject, args.dereferenceDepth)
+		if err != nil {
+			return err
+		}
+		openApiObject = *dereferenced
+	}
+	if args.extractInline {
+		extracted, err := transform.ExtractInlineSchemas(&openApiObject)
+		if err != nil {
+			return err
+		}
+		openApiObject = *extracted
+	}
+
+	// every requested output is written, the --output file too when it is given or nothing else is
+	fw := writer.NewFileWriter()
+	written := false
+	if args.outputDir != "" {
+		if err := fw.WriteTree(openApiObject, args.outputDir); err != nil {
+			return err
+		}
+		written = true
+	}
+	if args.goOut != "" {
+		if err := fw.WriteGo(openApiObject, args.goOut, args.goPackage, args.goObject); err != nil {
+			return err
+		}
+		written = true
+	}
+	if args.jsonSchemaOut != "" {
+		if err := fw.WriteJSONSchemas(openApiObject, args.jsonSchemaOut, args.jsonSchemaDraft, args.jsonSchemaRefs); err != nil {
+			return err
+		}
+		written = true
+	}
+	if args.postmanOut != "" {
+		if err := postman.WriteCollection(&openApiObject, args.postmanOut); err != nil {
+			return err
+		}
+		written = true
+	}
+	if args.htmlOut != "" {
+		if err := docs.WriteHTML(&openApiObject, args.htmlOut); err != nil {
+			return err
+		}
+		written = true
+	}
+	if args.markdownOut != "" {
+		if err := docs.WriteMarkdown(&openApiObject, args.markdownOut); err != nil {
+			return err
+		}
+		written = true
+	}
+	if written && !args.outputSet {
+		return nil
+	}
+	return fw.Write(openApiObject, args.output, args.generateYaml)
+}
+
+// prune removes the unused components and reports them
+func prune(openApiObject *oas.OpenAPIObject, args *args) error {
+	orphans, err := transform.Prune(openApiObject)
+	if err != nil {
+		return err
+	}
+	var report strings.Builder
+	for _, orphan := range orphans {
+		log.Infof("Unused %s removed", orphan)
+		fmt.Fprintln(&report, orphan)
+	}
+	if args.pruneReport == "" {
+		return nil
+	}
+	if err := ioutil.WriteFile(args.pruneReport, []byte(report.String()), 0644); err != nil {
+		return fmt.Errorf("Can not create the file %s: %v", args.pruneReport, err)
+	}
+	return nil
+}
+
+func bundleAction(c *cli.Context) error {
+	args := LoadArgs(c)
+	if c.NArg() != 1 {
+		return fmt.Errorf("bundle expects the root file of the spec as argument")
+	}
+	openApiObject, err := reader.Bundle(c.Args().First())
+	if err != nil {
+		return err
+	}
+	return writer.NewFileWriter().Write(openApiObject, args.output, args.generateYaml)
+}
+
+func mergeAction(c *cli.Context) error {
+	args := LoadArgs(c)
+	if c.NArg() < 2 {
+		return fmt.Errorf("merge expects at least two spec files as arguments")
+	}
+	prefixes := map[string]string{}
+	for _, prefix := range args.prefixes {
+		serviceAndPrefix := strings.SplitN(prefix, "=", 2)
+		if len(serviceAndPrefix) != 2 {
+			return fmt.Errorf("invalid prefix %s, expected service=/prefix", prefix)
+		}
+		prefixes[serviceAndPrefix[0]] = serviceAndPrefix[1]
+	}
+
+	var specs []merger.Spec
+	for _, arg := range c.Args() {
+		service, file := "", arg
+		if serviceAndFile := strings.SplitN(arg, "=", 2); len(serviceAndFile) == 2 {
+			service, file = serviceAndFile[0], serviceAndFile[1]
+		} else {
+			service = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		}
+		openApiObject, err := reader.NewFileReader().Read(file)
+		if err != nil {
+			return err
+		}
+		specs = append(specs, merger.Spec{Service: service, Prefix: prefixes[service], OpenAPI: openApiObject})
+	}
+
+	openApiObject, report, err := merger.Merge(specs)
+	if err != nil {
+		return err
+	}
+	for _, rename := range report.Renames {
+		log.Infof("Merge: %s", rename)
+	}
+	for _, collision := range report.Collisions {
+		log.Warnf("Merge collision: %s", collision)
+	}
+	if args.strict && len(report.Collisions) != 0 {
+		return fmt.Errorf("%d collisions while merging the specs", len(report.Collisions))
+	}
+	return writer.NewFileWriter().Write(openApiObject, args.output, args.generateYaml)
+}
+
+func mockAction(c *cli.Context) error {
+	args := LoadArgs(c)
+	openApiObject, err := parse(args)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Mock server listening on %s ...", args.addr)
+	return http.ListenAndServe(args.addr, mock.NewServer(&openApiObject))
+}
+
+func clientAction(c *cli.Context) error {
+	args := LoadArgs(c)
+	openApiObject, err := parse(args)
+	if err != nil {
+		return err
+	}
+
+	files, err := generator.GenerateClient(&openApiObject, generator.ClientOptions{
+		Package:     args.packageName,
+		ImportTypes: args.importTypes,
+	})
+	if err != nil {
+		return err
+	}
+	log.Infof("Writing go client to %s ...", args.out)
+	return files.Write(args.out)
+}
+
+func serverAction(c *cli.Context) error {
+	args := LoadArgs(c)
+	var openApiObject oas.OpenAPIObject
+	var err error
+	if args.spec != "" {
+		openApiObject, err = reader.NewFileReader().Read(args.spec)
+	} else {
+		openApiObject, err = parse(args)
+	}
+	if err != nil {
+		return err
+	}
+
+	modelsPath := args.modelsPath
+	if modelsPath == "" {
+		if modelsPath, err = modelsImportPath(args.out); err != nil {
+			return err
+		}
+	}
+	files, err := generator.GenerateServer(&openApiObject, generator.ServerOptions{
+		Package:          args.packageName,
+		ModelsImportPath: modelsPath,
+		Router:           args.router,
+	})
+	if err != nil {
+		return err
+	}
+	log.Infof("Writing go server to %s ...", args.out)
+	return files.Write(args.out)
+}
+
+func typescriptAction(c *cli.Context) error {
+	args := LoadArgs(c)
+	var openApiObject oas.OpenAPIObject
+	var err error
+	if args.spec != "" {
+		openApiObject, err = reader.NewFileReader().Read(args.spec)
+	} else {
+		openApiObject, err = parse(args)
+	}
+	if err != nil {
+		return err
+	}
+
+	source, err := generator.GenerateTypeScript(&openApiObject)
+	if err != nil {
+		return err
+	}
+	log.Infof("Writing typescript types to %s ...", args.out)
+	return generator.Files{filepath.Base(args.out): source}.Write(filepath.Dir(args.out))
+}
+
+func importAction(c *cli.Context) error {
+	args := LoadArgs(c)
+	if c.NArg() != 1 {
+		return fmt.Errorf("import expects the spec file as argument")
+	}
+	openApiObject, err := reader.NewFileReader().Read(c.Args().First())
+	if err != nil {
+		return err
+	}
+
+	modelsPath := args.modelsPath
+	if modelsPath == "" {
+		if modelsPath, err = modelsImportPath(args.out); err != nil {
+			return err
+		}
+	}
+	files, err := generator.GenerateAnnotated(&openApiObject, generator.AnnotatedOptions{
+		Package:          args.packageName,
+		ModelsImportPath: modelsPath,
+	})
+	if err != nil {
+		return err
+	}
+	log.Infof("Writing annotated go code to %s ...", args.out)
+	return files.Write(args.out)
+}
+
+// modelsImportPath finds the import path of the model package generated under out from the
+// go.mod of the enclosing module
+func modelsImportPath(out string) (string, error) {
+	dir, err := filepath.Abs(out)
+	if err != nil {
+		return "", err
+	}
+	for moduleDir := dir; ; moduleDir = filepath.Dir(moduleDir) {
+		goModPath := filepath.Join(moduleDir, "go.mod")
+		if _, err := os.Stat(goModPath); err == nil {
+			rel, err := filepath.Rel(moduleDir, dir)
+			if err != nil {
+				return "", err
+			}
+			return path.Join(utils.GetModuleNameFromGoMod(goModPath), filepath.ToSlash(rel), "model"), nil
+		}
+		if filepath.Dir(moduleDir) == moduleDir {
+			return "", fmt.Errorf("can not find the go.mod of %s, set --models-import-path", out)
+		}
+	}
+}
+
+func parse(args *args) (oas.OpenAPIObject, error) {
+	schemaNames := map[string]string{}
+	for _, schemaName := range args.schemaNames {
+		typeAndName := strings.SplitN(schemaName, "=", 2)
+		if len(typeAndName) != 2 {
+			return oas.OpenAPIObject{}, fmt.Errorf("invalid schema name %s, expected type=name", schemaName)
+		}
+		schemaNames[typeAndName[0]] = typeAndName[1]
+	}
 	parser, err := parserPkg.NewParser(
 		args.modulePath,
 		args.mainFilePath,
@@ -37,16 +388,19 @@ func action(c *cli.Context) error {
 		args.debug,
 		args.strict,
 		args.schemaWithoutPkg,
-	).Init()
+	).SchemaCollision(args.schemaCollision).SchemaNaming(args.schemaNaming, schemaNames).
+		TypeMappingFile(args.typeMappingFile).HoistInlineStructs(args.hoistInline).
+		JSONNaming(args.jsonNaming).RequiredPolicy(args.requiredPolicy).DirectionVariants(args.directions).Init()
 
 	if err != nil {
-		return err
+		return oas.OpenAPIObject{}, err
 	}
 	openApiObject, err := parser.Parse()
 	if err != nil {
-		return err
+		return oas.OpenAPIObject{}, err
 	}
-
-	fw := writer.NewFileWriter()
-	return fw.Write(openApiObject, args.output, args.generateYaml, args.schemaWithoutPkg)
+	if !args.schemaWithoutPkg {
+		writer.FilterSchemaWithoutPkg(openApiObject)
+	}
+	return openApiObject, nil
 }
@@ -3,6 +3,7 @@ package app
 import (
 	"strings"
 
+	parserPkg "github.com/parvez3019/go-swagger3/parser"
 	"github.com/urfave/cli"
 )
 
@@ -13,10 +14,43 @@ type args struct {
 	mainFilePath     string
 	handlerPath      string
 	output           string
+	outputSet        bool
+	outputDir        string
+	goOut            string
+	goPackage        string
+	goObject         bool
+	htmlOut          string
+	markdownOut      string
+	postmanOut       string
+	jsonSchemaOut    string
+	jsonSchemaDraft  string
+	jsonSchemaRefs   string
+	dereference      bool
+	dereferenceDepth int
+	extractInline    bool
+	pruneUnused      bool
+	pruneReport      string
 	debug            bool
 	strict           bool
 	schemaWithoutPkg bool
+	schemaCollision  string
+	schemaNaming     string
+	schemaNames      []string
+	typeMappingFile  string
+	hoistInline      bool
+	jsonNaming       string
+	requiredPolicy   string
+	directions       bool
 	generateYaml     bool
+
+	addr        string
+	packageName string
+	out         string
+	importTypes bool
+	spec        string
+	router      string
+	modelsPath  string
+	prefixes    []string
 }
 
 func LoadArgs(c *cli.Context) *args {
@@ -26,10 +60,46 @@ func LoadArgs(c *cli.Context) *args {
 		mainFilePath:     c.GlobalString("main-file-path"),
 		handlerPath:      c.GlobalString("handler-path"),
 		output:           c.GlobalString("output"),
+		outputSet:        c.GlobalIsSet("output"),
+		outputDir:        c.GlobalString("output-dir"),
+		goOut:            c.GlobalString("go-out"),
+		goPackage:        c.GlobalString("go-package"),
+		goObject:         c.GlobalBool("go-object"),
+		htmlOut:          c.GlobalString("html-out"),
+		markdownOut:      c.GlobalString("markdown-out"),
+		postmanOut:       c.GlobalString("postman-out"),
+		jsonSchemaOut:    c.GlobalString("jsonschema-out"),
+		jsonSchemaDraft:  c.GlobalString("jsonschema-draft"),
+		jsonSchemaRefs:   c.GlobalString("jsonschema-refs"),
+		dereference:      c.GlobalBool("dereference"),
+		dereferenceDepth: c.GlobalInt("dereference-depth"),
+		extractInline:    c.GlobalBool("extract-inline"),
+		pruneUnused:      c.GlobalBool("prune-unused"),
+		pruneReport:      c.GlobalString("prune-report"),
 		debug:            c.GlobalBool("debug"),
 		strict:           c.GlobalBool("strict"),
 		schemaWithoutPkg: c.GlobalBool("schema-without-pkg"),
+		schemaCollision:  c.GlobalString("schema-collision"),
+		schemaNaming:     c.GlobalString("schema-naming"),
+		schemaNames:      c.GlobalStringSlice("schema-name"),
+		typeMappingFile:  c.GlobalString("type-mapping-file"),
+		hoistInline:      c.GlobalBool("hoist-inline-structs"),
+		jsonNaming:       c.GlobalString("json-naming"),
+		requiredPolicy:   c.GlobalString("required-policy"),
+		directions:       c.GlobalBool("direction-variants"),
 		generateYaml:     c.GlobalBool("generate-yaml"),
+		addr:             c.String("addr"),
+		packageName:      c.String("package"),
+		out:              c.String("out"),
+		importTypes:      c.Bool("import-types"),
+		spec:             c.String("spec"),
+		router:           c.String("router"),
+		modelsPath:       c.String("models-import-path"),
+		prefixes:         c.StringSlice("prefix"),
+	}
+	if appArgs.schemaNaming != "" && appArgs.schemaNaming != parserPkg.SchemaNamingFull {
+		// the schemas are named by the parser, there is no package to filter
+		appArgs.schemaWithoutPkg = true
 	}
 	if appArgs.generateYaml && strings.HasSuffix(appArgs.output, ".json") {
 		appArgs.output = strings.TrimSuffix(appArgs.output, ".json") + ".yml"
@@ -57,7 +127,78 @@ var flags = []cli.Flag{
 	cli.StringFlag{
 		Name:  "output",
 		Value: "oas.json",
-		Usage: "output file",
+		Usage: "output file, written along with the other outputs only when given",
+	},
+	cli.StringFlag{
+		Name:  "output-dir",
+		Value: "",
+		Usage: "write the spec as a tree of yaml files with relative references into this directory, the output file is not written unless --output is given",
+	},
+	cli.StringFlag{
+		Name:  "go-out",
+		Value: "",
+		Usage: "write the spec embedded in a go file with http handlers serving it, the output file is not written unless --output is given",
+	},
+	cli.StringFlag{
+		Name:  "go-package",
+		Value: "",
+		Usage: "package name of the --go-out file, the name of its directory made an identifier when empty",
+	},
+	cli.BoolFlag{
+		Name:  "go-object",
+		Usage: "add a function returning the parsed spec to the --go-out file, it imports go-swagger3",
+	},
+	cli.StringFlag{
+		Name:  "html-out",
+		Value: "",
+		Usage: "write the spec as a static html site into this directory, the output file is not written unless --output is given",
+	},
+	cli.StringFlag{
+		Name:  "markdown-out",
+		Value: "",
+		Usage: "write the spec as a markdown file, the output file is not written unless --output is given",
+	},
+	cli.StringFlag{
+		Name:  "postman-out",
+		Value: "",
+		Usage: "write a postman v2.1 collection of the operations, the output file is not written unless --output is given",
+	},
+	cli.StringFlag{
+		Name:  "jsonschema-out",
+		Value: "",
+		Usage: "write every component schema as a standalone json schema into this directory, the output file is not written unless --output is given",
+	},
+	cli.StringFlag{
+		Name:  "jsonschema-draft",
+		Value: "2020-12",
+		Usage: "json schema draft of --jsonschema-out: 2020-12 or draft-07",
+	},
+	cli.StringFlag{
+		Name:  "jsonschema-refs",
+		Value: "files",
+		Usage: "how the --jsonschema-out schemas reference each other: files or defs",
+	},
+	cli.BoolFlag{
+		Name:  "dereference",
+		Usage: "inline the schema, parameter and response references of the operations",
+	},
+	cli.IntFlag{
+		Name:  "dereference-depth",
+		Value: 0,
+		Usage: "how many times --dereference expands a recursive type inside itself, 0 keeps the recursive reference",
+	},
+	cli.BoolFlag{
+		Name:  "extract-inline",
+		Usage: "move the inline object schemas found more than once into component schemas",
+	},
+	cli.BoolFlag{
+		Name:  "prune-unused",
+		Usage: "remove the schemas, parameters and security schemes no operation uses",
+	},
+	cli.StringFlag{
+		Name:  "prune-report",
+		Value: "",
+		Usage: "file listing the components --prune-unused removed",
 	},
 	cli.BoolFlag{
 		Name:  "debug",
@@ -71,8 +212,136 @@ var flags = []cli.Flag{
 		Name:  "schema-without-pkg",
 		Usage: "create schemas without package name append to the name",
 	},
+	cli.StringFlag{
+		Name:  "schema-collision",
+		Value: "rename",
+		Usage: "rename the go types given the same schema name after their package or fail: rename or fail",
+	},
+	cli.StringFlag{
+		Name:  "schema-naming",
+		Value: "",
+		Usage: "how the schemas are named: full, pkg, type or a template such as {{.Pkg | title}}{{.Type}}",
+	},
+	cli.StringSliceFlag{
+		Name:  "schema-name",
+		Usage: "name of the schema of a go type, eg. model.User=PublicUser, can be repeated",
+	},
+	cli.StringFlag{
+		Name:  "type-mapping-file",
+		Value: "",
+		Usage: "yaml or json file of the schemas go types are documented with, by import path and name",
+	},
+	cli.BoolFlag{
+		Name:  "hoist-inline-structs",
+		Usage: "create components of the anonymous structs named after their parent and field, eg. OrderItems",
+	},
+	cli.StringFlag{
+		Name:  "json-naming",
+		Value: "std",
+		Usage: "how the struct fields are named: std (encoding/json), camel, snake or protojson",
+	},
+	cli.StringFlag{
+		Name:  "required-policy",
+		Value: "explicit",
+		Usage: "rules making the properties required: explicit, validate or non-pointer, prefixed with request: or response: to apply in one direction, eg. validate,response:non-pointer",
+	},
+	cli.BoolFlag{
+		Name:  "direction-variants",
+		Usage: "split the schemas differing between the requests and the responses, eg. UserInput without the readOnly properties of User",
+	},
 	cli.BoolFlag{
 		Name:  "generate-yaml",
 		Usage: "generate yaml spec if true",
 	},
 }
+
+var mockFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "addr",
+		Value: ":8080",
+		Usage: "address the mock server listens on",
+	},
+}
+
+var clientFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "package",
+		Value: "client",
+		Usage: "package name of the generated client",
+	},
+	cli.StringFlag{
+		Name:  "out",
+		Value: "./client",
+		Usage: "directory the client is generated into",
+	},
+	cli.BoolFlag{
+		Name:  "import-types",
+		Usage: "use the go types of the annotations instead of generating models",
+	},
+}
+
+var serverFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "spec",
+		Value: "",
+		Usage: "spec file (json or yaml) to generate from, the module is parsed when empty",
+	},
+	cli.StringFlag{
+		Name:  "package",
+		Value: "api",
+		Usage: "package name of the generated server",
+	},
+	cli.StringFlag{
+		Name:  "out",
+		Value: "./api",
+		Usage: "directory the server is generated into, the models go to its model sub directory",
+	},
+	cli.StringFlag{
+		Name:  "router",
+		Value: "nethttp",
+		Usage: "router of the generated handlers: nethttp or chi",
+	},
+	cli.StringFlag{
+		Name:  "models-import-path",
+		Value: "",
+		Usage: "import path of the generated model package, found from the go.mod above the output directory when empty",
+	},
+}
+
+var typescriptFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "spec",
+		Value: "",
+		Usage: "spec file (json or yaml) to generate from, the module is parsed when empty",
+	},
+	cli.StringFlag{
+		Name:  "out",
+		Value: "./api.ts",
+		Usage: "typescript file to generate, .ts or .d.ts",
+	},
+}
+
+var importFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "package",
+		Value: "api",
+		Usage: "package name of the generated handlers",
+	},
+	cli.StringFlag{
+		Name:  "out",
+		Value: "./api",
+		Usage: "directory the handlers are generated into, the models go to its model sub directory",
+	},
+	cli.StringFlag{
+		Name:  "models-import-path",
+		Value: "",
+		Usage: "import path of the generated model package, found from the go.mod above the output directory when empty",
+	},
+}
+
+var mergeFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "prefix",
+		Usage: "path prefix of a service, eg. users=/users, repeat the flag for every service",
+	},
+}
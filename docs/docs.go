@@ -0,0 +1,463 @@
+package docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/parvez3019/go-swagger3/mock"
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+)
+
+// defaultTag groups the operations without tag
+const defaultTag = "default"
+
+// document is the spec laid out for reading: the operations grouped by tag with their params,
+// bodies and responses flattened into tables
+type document struct {
+	Info     oas.InfoObject
+	Servers  []oas.ServerObject
+	Security []securityRequirement
+	Tags     []tag
+}
+
+type tag struct {
+	Name        string
+	Description string
+	// File is the page of the tag in the html site
+	File       string
+	Anchor     string
+	Operations []operation
+}
+
+type operation struct {
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	OperationID string
+	Anchor      string
+	Parameters  []parameter
+	RequestBody *content
+	Responses   []response
+	Security    []securityRequirement
+}
+
+type parameter struct {
+	Name        string
+	In          string
+	Type        string
+	Required    bool
+	Description string
+	Example     string
+}
+
+// content is a body with its schema expanded into rows and an example
+type content struct {
+	ContentType string
+	Description string
+	Required    bool
+	Rows        []schemaRow
+	Example     string
+}
+
+type response struct {
+	Status      string
+	Description string
+	Content     *content
+}
+
+// schemaRow is a property of a body, nested properties follow their parent one level deeper
+type schemaRow struct {
+	Depth int
+	// Path is the dotted path of the property, eg. "users[].address.city"
+	Path        string
+	Name        string
+	Type        string
+	Required    bool
+	Description string
+	Constraints string
+}
+
+type securityRequirement struct {
+	Name        string
+	Scheme      string
+	Description string
+	Scopes      []string
+}
+
+type builder struct {
+	openAPI *oas.OpenAPIObject
+}
+
+// newDocument groups the operations of the spec by tag, in the order the tags are declared
+// followed by the other tags sorted by name
+func newDocument(openAPI *oas.OpenAPIObject) document {
+	b := builder{openAPI: openAPI}
+	doc := document{Info: openAPI.Info, Servers: openAPI.Servers, Security: b.securityRequirements(openAPI.Security)}
+
+	byTag := map[string][]operation{}
+	paths := make([]string, 0, len(openAPI.Paths))
+	for path := range openAPI.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		for _, method := range oas.Methods {
+			op := openAPI.Paths[path].Operation(method)
+			if op == nil {
+				continue
+			}
+			tags := op.Tags
+			if len(tags) == 0 {
+				tags = []string{defaultTag}
+			}
+			for _, name := range tags {
+				byTag[name] = append(byTag[name], b.operation(method, path, op))
+			}
+		}
+	}
+
+	var names []string
+	descriptions := map[string]string{}
+	for _, declared := range openAPI.Tags {
+		if _, ok := byTag[declared.Name]; ok {
+			names = append(names, declared.Name)
+			descriptions[declared.Name] = declared.Description
+		}
+	}
+	var others []string
+	for name := range byTag {
+		if _, ok := descriptions[name]; !ok {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	for _, name := range append(names, others...) {
+		doc.Tags = append(doc.Tags, tag{
+			Name:        name,
+			Description: descriptions[name],
+			File:        "tag-" + slug(name) + ".html",
+			Anchor:      "tag-" + slug(name),
+			Operations:  byTag[name],
+		})
+	}
+	return doc
+}
+
+func (b builder) operation(method, path string, op *oas.OperationObject) operation {
+	anchor := op.OperationID
+	if anchor == "" {
+		anchor = strings.ToLower(method) + "-" + path
+	}
+	o := operation{
+		Method:      method,
+		Path:        path,
+		Summary:     op.Summary,
+		Description: strings.TrimSpace(op.Description),
+		OperationID: op.OperationID,
+		Anchor:      "op-" + slug(anchor),
+		Security:    b.securityRequirements(b.openAPI.Security),
+	}
+	for i := range op.Parameters {
+		p := b.openAPI.ResolveParameter(&op.Parameters[i])
+		o.Parameters = append(o.Parameters, parameter{
+			Name:        p.Name,
+			In:          p.In,
+			Type:        b.typeOf(p.Schema),
+			Required:    p.Required,
+			Description: strings.TrimSpace(p.Description),
+			Example:     exampleString(p.Example),
+		})
+	}
+	if op.RequestBody != nil {
+		for _, contentType := range sortedContentTypes(op.RequestBody.Content) {
+			o.RequestBody = b.content(contentType, op.RequestBody.Content[contentType])
+			o.RequestBody.Description = strings.TrimSpace(op.RequestBody.Description)
+			o.RequestBody.Required = op.RequestBody.Required
+			break
+		}
+	}
+
+	statuses := make([]string, 0, len(op.Responses))
+	for status := range op.Responses {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		r := response{Status: status, Description: strings.TrimSpace(op.Responses[status].Description)}
+		for _, contentType := range sortedContentTypes(op.Responses[status].Content) {
+			r.Content = b.content(contentType, op.Responses[status].Content[contentType])
+			break
+		}
+		o.Responses = append(o.Responses, r)
+	}
+	return o
+}
+
+func (b builder) content(contentType string, mediaType *oas.MediaTypeObject) *content {
+	c := &content{ContentType: contentType}
+	if mediaType == nil {
+		return c
+	}
+	schema := &mediaType.Schema
+	b.appendRows(&c.Rows, schema, "", 0, map[string]bool{})
+	if len(c.Rows) == 0 && (schema.Type != "" || schema.Ref != "") {
+		c.Rows = []schemaRow{{Type: b.typeOf(schema), Description: schema.Description, Constraints: constraints(b.openAPI.ResolveSchema(schema))}}
+	}
+	if example := mock.Example(b.openAPI, schema); example != nil {
+		if out, err := json.MarshalIndent(example, "", "  "); err == nil {
+			c.Example = string(out)
+		}
+	}
+	return c
+}
+
+// appendRows expands the properties of an object, following the references and the items of the
+// arrays. A recursive type is expanded once.
+func (b builder) appendRows(rows *[]schemaRow, schema *oas.SchemaObject, path string, depth int, seen map[string]bool) {
+	if schema == nil {
+		return
+	}
+	if schema.Ref != "" {
+		if seen[schema.Ref] {
+			return
+		}
+		seen = withRef(seen, schema.Ref)
+	}
+	resolved := b.openAPI.ResolveSchema(schema)
+	if resolved.Type == "array" && resolved.Items != nil {
+		b.appendRows(rows, resolved.Items, path+"[]", depth, seen)
+		return
+	}
+	if resolved.AdditionalProperties != nil && resolved.AdditionalProperties.Schema != nil {
+		b.appendRows(rows, resolved.AdditionalProperties.Schema, path+"{}", depth, seen)
+	}
+	if resolved.Properties == nil {
+		return
+	}
+	for _, name := range resolved.Properties.Keys() {
+		property, ok := resolved.PropertySchema(name)
+		if !ok {
+			continue
+		}
+		propertyPath := name
+		if path != "" {
+			propertyPath = path + "." + name
+		}
+		description := property.Description
+		if description == "" {
+			description = b.openAPI.ResolveSchema(property).Description
+		}
+		*rows = append(*rows, schemaRow{
+			Depth:       depth,
+			Path:        propertyPath,
+			Name:        name,
+			Type:        b.typeOf(property),
+			Required:    contains(resolved.Required, name),
+			Description: strings.TrimSpace(description),
+			Constraints: constraints(b.openAPI.ResolveSchema(property)),
+		})
+		b.appendRows(rows, property, propertyPath, depth+1, seen)
+	}
+}
+
+func withRef(seen map[string]bool, ref string) map[string]bool {
+	copied := map[string]bool{ref: true}
+	for key := range seen {
+		copied[key] = true
+	}
+	return copied
+}
+
+// typeOf is the type of a schema as shown in the tables, eg. "array of User" or "string (date-time)"
+func (b builder) typeOf(schema *oas.SchemaObject) string {
+	if schema == nil {
+		return ""
+	}
+	if schema.Ref != "" {
+		return strings.TrimPrefix(schema.Ref, oas.SchemaRefPrefix)
+	}
+	switch {
+	case schema.Type == "array":
+		return "array of " + b.typeOf(schema.Items)
+	case schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil:
+		return "map of " + b.typeOf(schema.AdditionalProperties.Schema)
+	case schema.Format != "":
+		return schema.Type + " (" + schema.Format + ")"
+	case schema.Type == "":
+		return "object"
+	}
+	return schema.Type
+}
+
+// constraints lists the validation keywords of a schema, eg. "enum: [a b], maxLength: 10"
+func constraints(schema *oas.SchemaObject) string {
+	if schema == nil {
+		return ""
+	}
+	var list []string
+	add := func(keyword string, value interface{}) {
+		list = append(list, fmt.Sprintf("%s: %v", keyword, value))
+	}
+	if schema.Enum != nil {
+		if values, err := json.Marshal(schema.Enum); err == nil {
+			add("enum", string(values))
+		}
+	}
+	if schema.Minimum != 0 || schema.ExclusiveMinimum {
+		add("minimum", strconv.FormatFloat(schema.Minimum, 'f', -1, 64))
+	}
+	if schema.Maximum != 0 || schema.ExclusiveMaximum {
+		add("maximum", strconv.FormatFloat(schema.Maximum, 'f', -1, 64))
+	}
+	if schema.ExclusiveMinimum {
+		list = append(list, "exclusiveMinimum")
+	}
+	if schema.ExclusiveMaximum {
+		list = append(list, "exclusiveMaximum")
+	}
+	if schema.MinLength != 0 {
+		add("minLength", schema.MinLength)
+	}
+	if schema.MaxLength != 0 {
+		add("maxLength", schema.MaxLength)
+	}
+	if schema.Pattern != "" {
+		add("pattern", schema.Pattern)
+	}
+	if schema.MinItems != 0 {
+		add("minItems", schema.MinItems)
+	}
+	if schema.MaxItems != 0 {
+		add("maxItems", schema.MaxItems)
+	}
+	if schema.UniqueItems {
+		list = append(list, "uniqueItems")
+	}
+	if schema.Nullable {
+		list = append(list, "nullable")
+	}
+	if schema.ReadOnly {
+		list = append(list, "readOnly")
+	}
+	if schema.WriteOnly {
+		list = append(list, "writeOnly")
+	}
+	if schema.Deprecated {
+		list = append(list, "deprecated")
+	}
+	return strings.Join(list, ", ")
+}
+
+func (b builder) securityRequirements(requirements []map[string][]string) []securityRequirement {
+	var list []securityRequirement
+	for _, requirement := range requirements {
+		names := make([]string, 0, len(requirement))
+		for name := range requirement {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			r := securityRequirement{Name: name, Scopes: requirement[name]}
+			if scheme, ok := b.openAPI.Components.SecuritySchemes[name]; ok && scheme != nil {
+				r.Scheme = schemeDescription(scheme)
+				r.Description = strings.TrimSpace(scheme.Description)
+			}
+			list = append(list, r)
+		}
+	}
+	return list
+}
+
+// schemeDescription tells how the credentials are sent, eg. "apiKey in header X-API-Key"
+func schemeDescription(scheme *oas.SecuritySchemeObject) string {
+	switch scheme.Type {
+	case "http":
+		return "http " + scheme.Scheme
+	case "apiKey":
+		return "apiKey in " + scheme.In + " " + scheme.Name
+	case "openIdConnect":
+		return "openIdConnect " + scheme.OpenIdConnectUrl
+	case "oauth2":
+		var flows []string
+		if scheme.OAuthFlows != nil {
+			if scheme.OAuthFlows.AuthorizationCode != nil {
+				flows = append(flows, "authorizationCode")
+			}
+			if scheme.OAuthFlows.Implicit != nil {
+				flows = append(flows, "implicit")
+			}
+			if scheme.OAuthFlows.ResourceOwnerPassword != nil {
+				flows = append(flows, "password")
+			}
+			if scheme.OAuthFlows.ClientCredentials != nil {
+				flows = append(flows, "clientCredentials")
+			}
+		}
+		return strings.TrimSpace("oauth2 " + strings.Join(flows, ", "))
+	}
+	return scheme.Type
+}
+
+func exampleString(example interface{}) string {
+	switch value := example.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	}
+	out, err := json.Marshal(example)
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
+
+// sortedContentTypes lists json first, the content documented by most operations
+func sortedContentTypes(content map[string]*oas.MediaTypeObject) []string {
+	contentTypes := make([]string, 0, len(content))
+	for contentType := range content {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Slice(contentTypes, func(i, j int) bool {
+		if (contentTypes[i] == oas.ContentTypeJson) != (contentTypes[j] == oas.ContentTypeJson) {
+			return contentTypes[i] == oas.ContentTypeJson
+		}
+		return contentTypes[i] < contentTypes[j]
+	})
+	return contentTypes
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// slug turns a name into an anchor or a file name, eg. "get-/users/{id}" -> "get-users-id"
+func slug(name string) string {
+	var out strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			out.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && out.Len() > 0 {
+			out.WriteByte('-')
+			dash = true
+		}
+	}
+	s := strings.TrimSuffix(out.String(), "-")
+	if s == "" {
+		return "root"
+	}
+	return s
+}
@@ -0,0 +1,61 @@
+package docs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iancoleman/orderedmap"
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func getDocsOpenAPIObject() *oas.OpenAPIObject {
+	nodeProperties := orderedmap.New()
+	nodeProperties.Set("name", &oas.SchemaObject{Type: "string", Description: "name of the node", MaxLength: 20})
+	nodeProperties.Set("children", &oas.SchemaObject{Type: "array", Items: &oas.SchemaObject{Ref: oas.SchemaRefPrefix + "Node"}})
+	return &oas.OpenAPIObject{
+		Version: oas.OpenAPIVersion,
+		Info:    oas.InfoObject{Title: "Tree API", Version: "1.0"},
+		Paths: oas.PathsObject{
+			"/nodes/{id}": {Get: &oas.OperationObject{
+				Tags:        []string{"nodes"},
+				Summary:     "Get a node",
+				OperationID: "GetNode",
+				Parameters:  []oas.ParameterObject{{Name: "id", In: "path", Required: true, Schema: &oas.SchemaObject{Type: "string"}}},
+				Responses: oas.ResponsesObject{"200": {Description: "the node", Content: map[string]*oas.MediaTypeObject{
+					oas.ContentTypeJson: {Schema: oas.SchemaObject{Ref: oas.SchemaRefPrefix + "Node"}},
+				}}},
+			}},
+			"/live": {Get: &oas.OperationObject{Responses: oas.ResponsesObject{"200": {Description: "alive"}}}},
+		},
+		Components: oas.ComponentsObject{
+			Schemas:         map[string]*oas.SchemaObject{"Node": {Type: "object", Properties: nodeProperties, Required: []string{"name"}}},
+			SecuritySchemes: map[string]*oas.SecuritySchemeObject{"ApiKey": {Type: "apiKey", In: "header", Name: "X-API-Key"}},
+		},
+		Security: []map[string][]string{{"ApiKey": {}}},
+		Tags:     []oas.TagObject{{Name: "nodes", Description: "The nodes of the tree"}},
+	}
+}
+
+func Test_Markdown(t *testing.T) {
+	markdown := string(Markdown(getDocsOpenAPIObject()))
+
+	assert.Contains(t, markdown, "- [nodes](#tag-nodes)\n  - [`GET /nodes/{id}`](#op-getnode) Get a node\n- [default](#tag-default)\n  - [`GET /live`](#op-get-live)\n")
+	assert.Contains(t, markdown, "| `id` | path | string | yes |  |  |\n")
+	assert.Contains(t, markdown, "| `name` | string | yes | name of the node | maxLength: 20 |\n")
+	assert.Contains(t, markdown, "| `children` | array of Node | no |  |  |\n")
+	assert.NotContains(t, markdown, "children[].name", "a recursive type is expanded once")
+	assert.Contains(t, markdown, "| ApiKey | apiKey in header X-API-Key |  |  |\n")
+}
+
+func Test_HTML(t *testing.T) {
+	pages, err := HTML(getDocsOpenAPIObject())
+
+	assert.NoError(t, err)
+	assert.Len(t, pages, 3)
+	assert.Contains(t, string(pages[IndexFile]), `<a href="tag-nodes.html#op-getnode"><code>/nodes/{id}</code></a>`)
+	nodes := string(pages["tag-nodes.html"])
+	assert.Contains(t, nodes, `<section class="operation" id="op-getnode">`)
+	assert.Contains(t, nodes, "<p>The nodes of the tree</p>")
+	assert.False(t, strings.Contains(nodes, "http://") || strings.Contains(nodes, "https://"), "the pages load nothing")
+}
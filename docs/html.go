@@ -0,0 +1,151 @@
+package docs
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	log "github.com/sirupsen/logrus"
+)
+
+// IndexFile is the entry page of the html site
+const IndexFile = "index.html"
+
+var htmlTemplates = template.Must(template.New("docs").Funcs(template.FuncMap{
+	"lower":  strings.ToLower,
+	"indent": func(depth int) string { return fmt.Sprintf("%dem", depth*2) },
+}).Parse(`
+{{define "layout"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { margin: 0; font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #222; display: flex; }
+nav { width: 16em; min-height: 100vh; padding: 1em; background: #f5f6f8; border-right: 1px solid #ddd; box-sizing: border-box; }
+nav a { display: block; color: #333; text-decoration: none; padding: .2em 0; }
+nav a.current { font-weight: bold; }
+main { flex: 1; padding: 1em 2em; max-width: 70em; }
+table { border-collapse: collapse; width: 100%; margin: .5em 0 1em; }
+th, td { border: 1px solid #ddd; padding: .3em .6em; text-align: left; vertical-align: top; font-size: .9em; }
+th { background: #f5f6f8; }
+pre { background: #f5f6f8; padding: .8em; overflow-x: auto; }
+code { font-family: Menlo, Consolas, monospace; }
+.operation { border-top: 1px solid #ddd; padding-top: .5em; margin-top: 1.5em; }
+.method { display: inline-block; min-width: 4.5em; padding: .1em .4em; border-radius: 3px; color: #fff; text-align: center; font-size: .85em; background: #777; }
+.method.get { background: #2f80ed; } .method.post { background: #27ae60; } .method.put, .method.patch { background: #f2994a; } .method.delete { background: #eb5757; }
+.required { color: #eb5757; }
+</style>
+</head>
+<body>
+<nav>
+<a href="index.html"{{if eq .Current ""}} class="current"{{end}}>{{.Doc.Info.Title}}</a>
+{{range .Doc.Tags}}<a href="{{.File}}"{{if eq $.Current .Name}} class="current"{{end}}>{{.Name}}</a>
+{{end}}</nav>
+<main>
+{{if .Tag}}{{template "tag" .Tag}}{{else}}{{template "index" .Doc}}{{end}}
+</main>
+</body>
+</html>
+{{end}}
+
+{{define "index"}}<h1>{{.Info.Title}}</h1>
+{{if .Info.Version}}<p>Version <code>{{.Info.Version}}</code></p>{{end}}
+{{if .Info.Description}}<p>{{.Info.Description}}</p>{{end}}
+{{if .Servers}}<h2>Servers</h2>
+<table><tr><th>URL</th><th>Description</th></tr>
+{{range .Servers}}<tr><td><code>{{.URL}}</code></td><td>{{.Description}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Security}}<h2>Security</h2>{{template "security" .Security}}{{end}}
+<h2>Operations</h2>
+{{range .Tags}}<h3><a href="{{.File}}">{{.Name}}</a></h3>
+<table>
+{{$file := .File}}{{range .Operations}}<tr><td><span class="method {{lower .Method}}">{{.Method}}</span></td><td><a href="{{$file}}#{{.Anchor}}"><code>{{.Path}}</code></a></td><td>{{.Summary}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}
+
+{{define "tag"}}<h1 id="{{.Anchor}}">{{.Name}}</h1>
+{{if .Description}}<p>{{.Description}}</p>{{end}}
+{{range .Operations}}{{template "operation" .}}{{end}}{{end}}
+
+{{define "operation"}}<section class="operation" id="{{.Anchor}}">
+<h2><span class="method {{lower .Method}}">{{.Method}}</span> <code>{{.Path}}</code></h2>
+{{if .Summary}}<p><strong>{{.Summary}}</strong></p>{{end}}
+{{if .Description}}<p>{{.Description}}</p>{{end}}
+{{if .OperationID}}<p>Operation ID: <code>{{.OperationID}}</code></p>{{end}}
+{{if .Security}}<h3>Security</h3>{{template "security" .Security}}{{end}}
+{{if .Parameters}}<h3>Parameters</h3>
+<table><tr><th>Name</th><th>In</th><th>Type</th><th>Required</th><th>Description</th><th>Example</th></tr>
+{{range .Parameters}}<tr><td><code>{{.Name}}</code></td><td>{{.In}}</td><td>{{.Type}}</td><td>{{if .Required}}<span class="required">yes</span>{{else}}no{{end}}</td><td>{{.Description}}</td><td>{{.Example}}</td></tr>
+{{end}}</table>{{end}}
+{{with .RequestBody}}<h3>Request body <code>{{.ContentType}}</code>{{if .Required}} <span class="required">required</span>{{end}}</h3>
+{{template "content" .}}{{end}}
+{{if .Responses}}<h3>Responses</h3>
+{{range .Responses}}<h4>{{.Status}} {{.Description}}</h4>
+{{with .Content}}<p>Content type <code>{{.ContentType}}</code></p>{{template "content" .}}{{end}}{{end}}{{end}}
+</section>
+{{end}}
+
+{{define "content"}}{{if .Description}}<p>{{.Description}}</p>{{end}}
+{{if .Rows}}<table><tr><th>Property</th><th>Type</th><th>Required</th><th>Description</th><th>Constraints</th></tr>
+{{range .Rows}}<tr><td style="padding-left: {{indent .Depth}}"><code>{{.Name}}</code></td><td>{{.Type}}</td><td>{{if .Required}}<span class="required">yes</span>{{else}}no{{end}}</td><td>{{.Description}}</td><td>{{.Constraints}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Example}}<p>Example</p><pre><code>{{.Example}}</code></pre>{{end}}{{end}}
+
+{{define "security"}}<table><tr><th>Name</th><th>Scheme</th><th>Scopes</th><th>Description</th></tr>
+{{range .}}<tr><td>{{.Name}}</td><td>{{.Scheme}}</td><td>{{range $i, $scope := .Scopes}}{{if $i}}, {{end}}<code>{{$scope}}</code>{{end}}</td><td>{{.Description}}</td></tr>
+{{end}}</table>{{end}}
+`))
+
+type htmlPage struct {
+	Title   string
+	Current string
+	Doc     document
+	Tag     *tag
+}
+
+// WriteHTML writes the spec as a static html site into dir, see HTML
+func WriteHTML(openAPI *oas.OpenAPIObject, dir string) error {
+	log.Infof("Writing html documentation to %s ...", dir)
+	pages, err := HTML(openAPI)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("Can not create the directory %s: %v", dir, err)
+	}
+	for name, page := range pages {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, page, 0644); err != nil {
+			return fmt.Errorf("Can not create the file %s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+// HTML renders the spec as html pages by file name: index.html with the general information and
+// the index of the operations, and a page per tag with its operations. The pages embed their
+// style and link to each other only, they work offline.
+func HTML(openAPI *oas.OpenAPIObject) (map[string][]byte, error) {
+	doc := newDocument(openAPI)
+	pages := map[string][]byte{}
+	var index bytes.Buffer
+	if err := htmlTemplates.ExecuteTemplate(&index, "layout", htmlPage{Title: doc.Info.Title, Doc: doc}); err != nil {
+		return nil, err
+	}
+	pages[IndexFile] = index.Bytes()
+	for i := range doc.Tags {
+		t := &doc.Tags[i]
+		var page bytes.Buffer
+		if err := htmlTemplates.ExecuteTemplate(&page, "layout", htmlPage{Title: t.Name + " - " + doc.Info.Title, Current: t.Name, Doc: doc, Tag: t}); err != nil {
+			return nil, err
+		}
+		pages[t.File] = page.Bytes()
+	}
+	return pages, nil
+}
@@ -0,0 +1,153 @@
+package docs
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	log "github.com/sirupsen/logrus"
+)
+
+// WriteMarkdown writes the spec as a single markdown file
+func WriteMarkdown(openAPI *oas.OpenAPIObject, path string) error {
+	log.Infof("Writing markdown documentation to %s ...", path)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("Can not create the directory %s: %v", filepath.Dir(path), err)
+	}
+	if err := ioutil.WriteFile(path, Markdown(openAPI), 0644); err != nil {
+		return fmt.Errorf("Can not create the file %s: %v", path, err)
+	}
+	return nil
+}
+
+// Markdown renders the spec as markdown: the general information, an index of the operations
+// and a section per tag with the params, bodies and responses of its operations
+func Markdown(openAPI *oas.OpenAPIObject) []byte {
+	doc := newDocument(openAPI)
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "# %s\n\n", doc.Info.Title)
+	if doc.Info.Version != "" {
+		fmt.Fprintf(&buffer, "Version: `%s`\n\n", doc.Info.Version)
+	}
+	if description := strings.TrimSpace(doc.Info.Description); description != "" {
+		fmt.Fprintf(&buffer, "%s\n\n", description)
+	}
+	if len(doc.Servers) != 0 {
+		buffer.WriteString("## Servers\n\n| URL | Description |\n| --- | --- |\n")
+		for _, server := range doc.Servers {
+			fmt.Fprintf(&buffer, "| %s | %s |\n", cell(server.URL), cell(strings.TrimSpace(server.Description)))
+		}
+		buffer.WriteString("\n")
+	}
+	if len(doc.Security) != 0 {
+		buffer.WriteString("## Security\n\n")
+		writeMarkdownSecurity(&buffer, doc.Security)
+	}
+
+	buffer.WriteString("## Operations\n\n")
+	for _, t := range doc.Tags {
+		fmt.Fprintf(&buffer, "- [%s](#%s)\n", t.Name, t.Anchor)
+		for _, op := range t.Operations {
+			fmt.Fprintf(&buffer, "  - %s\n", strings.TrimSpace(fmt.Sprintf("[`%s %s`](#%s) %s", op.Method, op.Path, op.Anchor, op.Summary)))
+		}
+	}
+	buffer.WriteString("\n")
+
+	for _, t := range doc.Tags {
+		fmt.Fprintf(&buffer, "<a id=\"%s\"></a>\n\n## %s\n\n", t.Anchor, t.Name)
+		if t.Description != "" {
+			fmt.Fprintf(&buffer, "%s\n\n", t.Description)
+		}
+		for _, op := range t.Operations {
+			writeMarkdownOperation(&buffer, op)
+		}
+	}
+	return buffer.Bytes()
+}
+
+func writeMarkdownOperation(buffer *bytes.Buffer, op operation) {
+	fmt.Fprintf(buffer, "<a id=\"%s\"></a>\n\n### `%s %s`\n\n", op.Anchor, op.Method, op.Path)
+	if op.Summary != "" {
+		fmt.Fprintf(buffer, "**%s**\n\n", op.Summary)
+	}
+	if op.Description != "" {
+		fmt.Fprintf(buffer, "%s\n\n", op.Description)
+	}
+	if op.OperationID != "" {
+		fmt.Fprintf(buffer, "Operation ID: `%s`\n\n", op.OperationID)
+	}
+	if len(op.Security) != 0 {
+		buffer.WriteString("#### Security\n\n")
+		writeMarkdownSecurity(buffer, op.Security)
+	}
+	if len(op.Parameters) != 0 {
+		buffer.WriteString("#### Parameters\n\n| Name | In | Type | Required | Description | Example |\n| --- | --- | --- | --- | --- | --- |\n")
+		for _, p := range op.Parameters {
+			fmt.Fprintf(buffer, "| `%s` | %s | %s | %s | %s | %s |\n", p.Name, p.In, cell(p.Type), yesNo(p.Required), cell(p.Description), cell(p.Example))
+		}
+		buffer.WriteString("\n")
+	}
+	if op.RequestBody != nil {
+		required := ""
+		if op.RequestBody.Required {
+			required = " (required)"
+		}
+		fmt.Fprintf(buffer, "#### Request body `%s`%s\n\n", op.RequestBody.ContentType, required)
+		writeMarkdownContent(buffer, op.RequestBody)
+	}
+	if len(op.Responses) != 0 {
+		buffer.WriteString("#### Responses\n\n")
+		for _, r := range op.Responses {
+			fmt.Fprintf(buffer, "##### %s\n\n", strings.TrimSpace(r.Status+" "+r.Description))
+			if r.Content != nil {
+				fmt.Fprintf(buffer, "Content type: `%s`\n\n", r.Content.ContentType)
+				writeMarkdownContent(buffer, r.Content)
+			}
+		}
+	}
+}
+
+func writeMarkdownContent(buffer *bytes.Buffer, c *content) {
+	if c.Description != "" {
+		fmt.Fprintf(buffer, "%s\n\n", c.Description)
+	}
+	if len(c.Rows) != 0 {
+		buffer.WriteString("| Property | Type | Required | Description | Constraints |\n| --- | --- | --- | --- | --- |\n")
+		for _, row := range c.Rows {
+			path := "`" + row.Path + "`"
+			if row.Path == "" {
+				path = ""
+			}
+			fmt.Fprintf(buffer, "| %s | %s | %s | %s | %s |\n", path, cell(row.Type), yesNo(row.Required), cell(row.Description), cell(row.Constraints))
+		}
+		buffer.WriteString("\n")
+	}
+	if c.Example != "" {
+		fmt.Fprintf(buffer, "Example:\n\n```json\n%s\n```\n\n", c.Example)
+	}
+}
+
+func writeMarkdownSecurity(buffer *bytes.Buffer, requirements []securityRequirement) {
+	buffer.WriteString("| Name | Scheme | Scopes | Description |\n| --- | --- | --- | --- |\n")
+	for _, r := range requirements {
+		fmt.Fprintf(buffer, "| %s | %s | %s | %s |\n", cell(r.Name), cell(r.Scheme), cell(strings.Join(r.Scopes, ", ")), cell(r.Description))
+	}
+	buffer.WriteString("\n")
+}
+
+// cell escapes a value for a markdown table cell
+func cell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(strings.TrimSpace(value), "\n", "<br>")
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
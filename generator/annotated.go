@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/transform"
+)
+
+type AnnotatedOptions struct {
+	Package string
+	// ModelsImportPath is the import path of the generated model package, the handlers import it
+	// for the parser to resolve the model types of the annotations
+	ModelsImportPath string
+}
+
+// GenerateAnnotated generates the annotated go code go-swagger3 parses the spec back from: the
+// general information in doc.go, a handler stub per operation and the models in a model package
+func GenerateAnnotated(openAPI *oas.OpenAPIObject, options AnnotatedOptions) (Files, error) {
+	if options.Package == "" {
+		options.Package = "api"
+	}
+	if options.ModelsImportPath == "" {
+		return nil, fmt.Errorf("the import path of the model package is required")
+	}
+	hoisted, err := transform.HoistInlineSchemas(openAPI)
+	if err != nil {
+		return nil, err
+	}
+	typeNames := ComponentTypeNames(hoisted)
+	operations := Operations(hoisted)
+	files := Files{}
+
+	var headerTypes map[string]string
+	files["model/models.go"], headerTypes, err = generateTaggedModels(hoisted, typeNames, operations)
+	if err != nil {
+		return nil, err
+	}
+	annotations := annotationWriter{openAPI: hoisted, qualifier: "model.", typeNames: typeNames, headerTypes: headerTypes}
+	files["doc.go"], err = generateDoc(options.Package, annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	var handlers bytes.Buffer
+	for _, operation := range operations {
+		annotations.writeOperation(&handlers, operation)
+		fmt.Fprintf(&handlers, "func %s(w http.ResponseWriter, r *http.Request) {\nw.WriteHeader(http.StatusNotImplemented)\n}\n\n", operation.Name)
+	}
+	files["handlers.go"], err = formatSource(options.Package, handlers.Bytes(), map[string]string{options.ModelsImportPath: "_"})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// generateTaggedModels generates the model package, see newTaggedModelMapper and writeHeaderModels
+func generateTaggedModels(openAPI *oas.OpenAPIObject, typeNames map[string]string, operations []Operation) ([]byte, map[string]string, error) {
+	mapper := newTaggedModelMapper(openAPI, typeNames)
+	var models, headers bytes.Buffer
+	headerTypes, headerComponent := mapper.writeHeaderModels(&headers, operations, typeNames)
+	mapper.writeTaggedModels(&models, typeNames, map[string]bool{headerComponent: true})
+	models.Write(headers.Bytes())
+	source, err := formatSource("model", models.Bytes(), mapper.imports)
+	return source, headerTypes, err
+}
+
+func generateDoc(packageName string, annotations annotationWriter) ([]byte, error) {
+	var doc bytes.Buffer
+	doc.WriteString("// The general API information, use this file as the main file of go-swagger3\n//\n")
+	annotations.writeInfo(&doc)
+	return formatSource(packageName, doc.Bytes(), nil)
+}
@@ -0,0 +1,17 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GenerateAnnotated(t *testing.T) {
+	files, err := GenerateAnnotated(getClientOpenAPIObject(), AnnotatedOptions{ModelsImportPath: "example.com/users/model"})
+
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+	assert.Contains(t, string(files["handlers.go"]), "import (\n\t\"net/http\"\n\n\t_ \"example.com/users/model\"\n)")
+	assert.Contains(t, string(files["handlers.go"]), "// @Route /users/{id} [delete]\nfunc DeleteUsersByID(w http.ResponseWriter, r *http.Request) {")
+	assert.Contains(t, string(files["model/models.go"]), "type Error struct {")
+}
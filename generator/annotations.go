@@ -0,0 +1,289 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/transform"
+)
+
+// annotationWriter writes the go-swagger3 comments the parser reads back into the same spec
+type annotationWriter struct {
+	openAPI *oas.OpenAPIObject
+	// qualifier prefixes the model types, the parser only resolves query models of a "model" package
+	qualifier string
+	// typeNames is the go type declared for every component schema
+	typeNames map[string]string
+	// headerTypes is the struct listing every set of header parameters, by headerSetKey
+	headerTypes map[string]string
+}
+
+// writeInfo writes the general API information
+func (a annotationWriter) writeInfo(buffer *bytes.Buffer) {
+	info := a.openAPI.Info
+	fmt.Fprintf(buffer, "// @Version %s\n", info.Version)
+	fmt.Fprintf(buffer, "// @Title %s\n", info.Title)
+	if info.Description != "" {
+		fmt.Fprintf(buffer, "// @Description %s\n", oneLine(info.Description))
+	}
+	if info.Contact != nil {
+		writeAnnotation(buffer, "@ContactName", info.Contact.Name)
+		writeAnnotation(buffer, "@ContactEmail", info.Contact.Email)
+	}
+	writeAnnotation(buffer, "@TermsOfServiceUrl", info.TermsOfService)
+	if info.License != nil {
+		writeAnnotation(buffer, "@LicenseName", info.License.Name)
+		writeAnnotation(buffer, "@LicenseURL", info.License.URL)
+	}
+	for _, server := range a.openAPI.Servers {
+		if len(a.openAPI.Servers) == 1 && server.URL == "/" && server.Description == "Default Server URL" {
+			continue
+		}
+		writeAnnotation(buffer, "@Server", server.URL, oneLine(server.Description))
+	}
+	for _, security := range a.openAPI.Security {
+		for _, name := range sortedKeys(security) {
+			fmt.Fprintf(buffer, "// @Security %s\n", strings.TrimSpace(name+" "+strings.Join(security[name], " ")))
+		}
+	}
+	a.writeSecuritySchemes(buffer)
+}
+
+func (a annotationWriter) writeSecuritySchemes(buffer *bytes.Buffer) {
+	schemes := a.openAPI.Components.SecuritySchemes
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		scheme := schemes[name]
+		switch scheme.Type {
+		case "http":
+			writeAnnotation(buffer, "@SecurityScheme", name, "http", scheme.Scheme, scheme.Description)
+		case "apiKey":
+			writeAnnotation(buffer, "@SecurityScheme", name, "apiKey", scheme.In, scheme.Name, scheme.Description)
+		case "openIdConnect":
+			writeAnnotation(buffer, "@SecurityScheme", name, "openIdConnect", scheme.OpenIdConnectUrl, scheme.Description)
+		case "oauth2":
+			if scheme.OAuthFlows == nil {
+				continue
+			}
+			flows := scheme.OAuthFlows
+			var scopes map[string]string
+			if flow := flows.AuthorizationCode; flow != nil {
+				writeAnnotation(buffer, "@SecurityScheme", name, "oauth2AuthCode", flow.AuthorizationUrl, flow.TokenUrl)
+				scopes = flow.Scopes
+			}
+			if flow := flows.Implicit; flow != nil {
+				writeAnnotation(buffer, "@SecurityScheme", name, "oauth2Implicit", flow.AuthorizationUrl)
+				scopes = flow.Scopes
+			}
+			if flow := flows.ResourceOwnerPassword; flow != nil {
+				writeAnnotation(buffer, "@SecurityScheme", name, "oauth2ResourceOwnerCredentials", flow.TokenUrl)
+				scopes = flow.Scopes
+			}
+			if flow := flows.ClientCredentials; flow != nil {
+				writeAnnotation(buffer, "@SecurityScheme", name, "oauth2ClientCredentials", flow.TokenUrl)
+				scopes = flow.Scopes
+			}
+			for _, scope := range sortedStringKeys(scopes) {
+				writeAnnotation(buffer, "@SecurityScope", name, scope, scopes[scope])
+			}
+		}
+	}
+}
+
+// writeOperation writes the comments of the handler of an operation
+func (a annotationWriter) writeOperation(buffer *bytes.Buffer, operation Operation) {
+	op := operation.Operation
+	writeAnnotation(buffer, "@Title", oneLine(op.Summary))
+	writeAnnotation(buffer, "@Description", oneLine(op.Description))
+
+	var headerRefs []string
+	for _, parameter := range op.Parameters {
+		if parameter.Ref != "" {
+			headerRefs = append(headerRefs, strings.TrimPrefix(parameter.Ref, oas.ParameterRefPrefix))
+		}
+	}
+	if typeName, ok := a.headerTypes[headerSetKey(headerRefs)]; ok {
+		fmt.Fprintf(buffer, "// @Header %s%s\n", a.qualifier, typeName)
+	}
+	for i := range op.Parameters {
+		if parameter := &op.Parameters[i]; parameter.Ref == "" {
+			a.writeParam(buffer, parameter)
+		}
+	}
+	a.writeRequestBody(buffer, op.RequestBody)
+
+	statuses := make([]string, 0, len(op.Responses))
+	for status := range op.Responses {
+		if _, err := strconv.Atoi(status); err == nil {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		a.writeResponse(buffer, status, op.Responses[status])
+	}
+
+	for _, tag := range op.Tags {
+		writeAnnotation(buffer, "@Resource", tag)
+	}
+	writeAnnotation(buffer, "@OperationId", op.OperationID)
+	fmt.Fprintf(buffer, "// @Route %s [%s]\n", operation.Path, strings.ToLower(operation.Method))
+}
+
+func (a annotationWriter) writeParam(buffer *bytes.Buffer, parameter *oas.ParameterObject) {
+	line := fmt.Sprintf("// @Param %s %s %s %t %s", parameter.Name, parameter.In, a.paramType(parameter.Schema),
+		parameter.Required, quoteDescription(parameter.Description, parameter.Name))
+	if example, ok := parameter.Example.(string); ok && example != "" {
+		line += " " + quoteDescription(example, example)
+	}
+	buffer.WriteString(line + "\n")
+}
+
+func (a annotationWriter) writeRequestBody(buffer *bytes.Buffer, requestBody *oas.RequestBodyObject) {
+	if requestBody == nil {
+		return
+	}
+	if mediaType, ok := requestBody.Content[oas.ContentTypeJson]; ok {
+		fmt.Fprintf(buffer, "// @Param request body %s %t %s\n", a.paramType(&mediaType.Schema), requestBody.Required,
+			quoteDescription(requestBody.Description, "Request body"))
+		return
+	}
+	mediaType, ok := requestBody.Content[oas.ContentTypeForm]
+	if !ok || mediaType.Schema.Properties == nil {
+		return
+	}
+	for _, name := range mediaType.Schema.Properties.Keys() {
+		property, _ := mediaType.Schema.PropertySchema(name)
+		if property.Format == "binary" {
+			fmt.Fprintf(buffer, "// @Param %s file file %t %s\n", name, requestBody.Required, quoteDescription(property.Description, name))
+			continue
+		}
+		fmt.Fprintf(buffer, "// @Param %s form %s %t %s\n", name, a.paramType(property), requestBody.Required,
+			quoteDescription(property.Description, name))
+	}
+}
+
+func (a annotationWriter) writeResponse(buffer *bytes.Buffer, status string, response *oas.ResponseObject) {
+	attribute := "@Success"
+	if !strings.HasPrefix(status, "2") {
+		attribute = "@Failure"
+	}
+	description := strconv.Quote(strings.ReplaceAll(oneLine(response.Description), `"`, "'"))
+	if mediaType, ok := response.Content[oas.ContentTypeJson]; ok {
+		if typeName := a.responseType(&mediaType.Schema); typeName != "" {
+			fmt.Fprintf(buffer, "// %s %s %s %s\n", attribute, status, typeName, description)
+			return
+		}
+	}
+	if _, ok := response.Content[oas.ContentTypeText]; ok {
+		fmt.Fprintf(buffer, "// %s %s {object} string %s\n", attribute, status, description)
+		return
+	}
+	fmt.Fprintf(buffer, "// %s %s %s\n", attribute, status, description)
+}
+
+// responseType is the json type followed by the go type of a json response, eg. "{array} []model.User"
+func (a annotationWriter) responseType(schema *oas.SchemaObject) string {
+	switch {
+	case schema.Ref != "":
+		return "{object} " + a.paramType(schema)
+	case schema.Type == "array" && schema.Items != nil:
+		return "{array} " + a.paramType(schema)
+	case schema.Type == "string" || schema.Type == "integer" || schema.Type == "boolean":
+		return "{" + schema.Type + "} " + schema.Type
+	}
+	return ""
+}
+
+// paramType is the go type the parser maps back to a schema
+func (a annotationWriter) paramType(schema *oas.SchemaObject) string {
+	if schema == nil {
+		return "string"
+	}
+	if schema.Ref != "" {
+		key := strings.TrimPrefix(schema.Ref, oas.SchemaRefPrefix)
+		if resolved := a.openAPI.ResolveSchema(schema); transform.IsEnumSchema(resolved) {
+			// enum params are referenced by the schema key
+			return a.qualifier + key
+		}
+		if typeName, ok := a.typeNames[key]; ok {
+			return a.qualifier + typeName
+		}
+		return "string"
+	}
+	switch schema.Type {
+	case "array":
+		return "[]" + a.paramType(schema.Items)
+	case "integer":
+		if schema.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		if schema.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "string":
+		if schema.Format == "date-time" {
+			return "time.Time"
+		}
+	}
+	return "string"
+}
+
+// headerSetKey identifies a list of header parameter refs
+func headerSetKey(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	return strings.Join(names, "\n")
+}
+
+// writeAnnotation writes an annotation unless its first value is empty
+func writeAnnotation(buffer *bytes.Buffer, attribute string, values ...string) {
+	if len(values) == 0 || values[0] == "" {
+		return
+	}
+	fmt.Fprintf(buffer, "// %s %s\n", attribute, strings.TrimSpace(strings.Join(values, " ")))
+}
+
+func quoteDescription(description, fallback string) string {
+	description = strings.TrimSpace(oneLine(description))
+	if description == "" {
+		description = fallback
+	}
+	return `"` + strings.ReplaceAll(description, `"`, "'") + `"`
+}
+
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(text, "\n", " ")), " ")
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,455 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/transform"
+)
+
+type ClientOptions struct {
+	Package string
+	// ImportTypes references the go types the schemas were parsed from instead of generating models.
+	// It only applies to an OpenAPIObject built by the parser, which knows the package of every type.
+	ImportTypes bool
+}
+
+// GenerateClient generates a go client with one method per operation of the spec
+func GenerateClient(openAPI *oas.OpenAPIObject, options ClientOptions) (Files, error) {
+	if options.Package == "" {
+		options.Package = "client"
+	}
+	mapper := newTypeMapper(openAPI)
+	skip := map[string]bool{}
+	if options.ImportTypes {
+		skip = importOriginalTypes(openAPI, mapper)
+	}
+
+	files := Files{}
+	var err error
+	files["client.go"], err = formatSource(options.Package, []byte(clientRuntime), mapper.imports)
+	if err != nil {
+		return nil, err
+	}
+
+	var models bytes.Buffer
+	mapper.writeModels(&models, skip)
+	files["models.go"], err = formatSource(options.Package, models.Bytes(), mapper.imports)
+	if err != nil {
+		return nil, err
+	}
+
+	var operations bytes.Buffer
+	for _, operation := range Operations(openAPI) {
+		clientOperation{typeMapper: mapper, Operation: operation}.write(&operations)
+	}
+	files["operations.go"], err = formatSource(options.Package, operations.Bytes(), mapper.imports)
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// importOriginalTypes points every component parsed from an exported go type to that type
+func importOriginalTypes(openAPI *oas.OpenAPIObject, mapper *typeMapper) map[string]bool {
+	imported := map[string]bool{}
+	for key, schema := range openAPI.Components.Schemas {
+		typeName := transform.LastSegment(key)
+		if schema == nil || schema.PkgName == "" || typeName == "" || !unicode.IsUpper([]rune(typeName)[0]) ||
+			strings.HasSuffix(schema.PkgName, "/main") || !strings.Contains(schema.PkgName, "/") {
+			continue
+		}
+		mapper.names[key] = mapper.importPackage(schema.PkgName) + "." + typeName
+		imported[key] = true
+	}
+	return imported
+}
+
+type clientOperation struct {
+	*typeMapper
+	Operation
+
+	pathParams  []*oas.ParameterObject
+	otherParams []*oas.ParameterObject
+}
+
+func (o clientOperation) write(buffer *bytes.Buffer) {
+	for i := range o.Operation.Operation.Parameters {
+		parameter := o.openAPI.ResolveParameter(&o.Operation.Operation.Parameters[i])
+		if parameter.In == "path" {
+			o.pathParams = append(o.pathParams, parameter)
+		} else if parameter.Name != "" {
+			o.otherParams = append(o.otherParams, parameter)
+		}
+	}
+	o.writeParamsStruct(buffer)
+	o.writeErrorTypes(buffer)
+	o.writeMethod(buffer)
+}
+
+func (o clientOperation) paramsTypeName() string {
+	return o.Name + "Params"
+}
+
+// hasRequiredParams tells whether a query, header or cookie param is required, the params struct is
+// then required too
+func (o clientOperation) hasRequiredParams() bool {
+	for _, parameter := range o.otherParams {
+		if parameter.Required {
+			return true
+		}
+	}
+	return false
+}
+
+func (o clientOperation) writeParamsStruct(buffer *bytes.Buffer) {
+	if len(o.otherParams) == 0 {
+		return
+	}
+	fmt.Fprintf(buffer, "// %s are the query, header and cookie params of %s\n", o.paramsTypeName(), o.Name)
+	fmt.Fprintf(buffer, "type %s struct {\n", o.paramsTypeName())
+	for _, parameter := range o.otherParams {
+		writeComment(buffer, GoName(parameter.Name), parameter.Description)
+		goType := o.goType(parameter.Schema)
+		if !parameter.Required {
+			goType = "*" + goType
+		}
+		fmt.Fprintf(buffer, "%s %s\n", GoName(parameter.Name), goType)
+	}
+	buffer.WriteString("}\n\n")
+}
+
+// failures are the documented responses with a non 2xx status, sorted by status
+func (o clientOperation) failures() []string {
+	var statuses []string
+	for status := range o.Operation.Operation.Responses {
+		if !strings.HasPrefix(status, "2") {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Strings(statuses)
+	return statuses
+}
+
+func (o clientOperation) errorTypeName(status string) string {
+	statusCode, err := strconv.Atoi(status)
+	if err != nil || http.StatusText(statusCode) == "" {
+		return o.Name + GoName(status) + "Error"
+	}
+	return o.Name + strings.TrimSuffix(GoName(http.StatusText(statusCode)), "Error") + "Error"
+}
+
+func (o clientOperation) writeErrorTypes(buffer *bytes.Buffer) {
+	for _, status := range o.failures() {
+		response := o.Operation.Operation.Responses[status]
+		typeName := o.errorTypeName(status)
+		bodyType := "[]byte"
+		if mediaType, ok := response.Content[oas.ContentTypeJson]; ok {
+			bodyType = o.goType(&mediaType.Schema)
+		}
+		fmt.Fprintf(buffer, "// %s is returned by %s for a %s response", typeName, o.Name, status)
+		if response.Description != "" {
+			fmt.Fprintf(buffer, ": %s", response.Description)
+		}
+		fmt.Fprintf(buffer, "\ntype %s struct {\nStatusCode int\nBody %s\n}\n\n", typeName, bodyType)
+		fmt.Fprintf(buffer, "func (e *%s) Error() string {\nreturn fmt.Sprintf(\"%s: status %%d\", e.StatusCode)\n}\n\n", typeName, o.Name)
+	}
+	if len(o.failures()) == 0 {
+		return
+	}
+
+	fmt.Fprintf(buffer, "func decode%sError(statusCode int, body []byte) error {\nswitch statusCode {\n", o.Name)
+	for _, status := range o.failures() {
+		if _, err := strconv.Atoi(status); err != nil {
+			continue
+		}
+		typeName := o.errorTypeName(status)
+		fmt.Fprintf(buffer, "case %s:\napiErr := &%s{StatusCode: statusCode}\n", status, typeName)
+		if _, ok := o.Operation.Operation.Responses[status].Content[oas.ContentTypeJson]; ok {
+			buffer.WriteString("if err := json.Unmarshal(body, &apiErr.Body); err != nil {\nreturn &APIError{StatusCode: statusCode, Body: body}\n}\n")
+		} else {
+			buffer.WriteString("apiErr.Body = body\n")
+		}
+		buffer.WriteString("return apiErr\n")
+	}
+	buffer.WriteString("}\n")
+	if _, ok := o.Operation.Operation.Responses["default"]; ok {
+		fmt.Fprintf(buffer, "apiErr := &%s{StatusCode: statusCode}\n", o.errorTypeName("default"))
+		if _, ok := o.Operation.Operation.Responses["default"].Content[oas.ContentTypeJson]; ok {
+			buffer.WriteString("if err := json.Unmarshal(body, &apiErr.Body); err == nil {\nreturn apiErr\n}\n")
+		} else {
+			buffer.WriteString("apiErr.Body = body\nreturn apiErr\n")
+		}
+	}
+	buffer.WriteString("return &APIError{StatusCode: statusCode, Body: body}\n}\n\n")
+}
+
+func (o clientOperation) writeMethod(buffer *bytes.Buffer) {
+	summary := o.Operation.Operation.Summary
+	if summary == "" {
+		summary = fmt.Sprintf("calls %s %s", o.Method, o.Path)
+	}
+	writeComment(buffer, o.Name, summary)
+	if description := strings.TrimSpace(o.Operation.Operation.Description); description != "" {
+		buffer.WriteString("//\n")
+		for _, line := range strings.Split(description, "\n") {
+			fmt.Fprintf(buffer, "// %s\n", strings.TrimSpace(line))
+		}
+	}
+
+	arguments := []string{"ctx context.Context"}
+	for _, parameter := range o.pathParams {
+		arguments = append(arguments, LowerGoName(parameter.Name)+" "+o.goType(parameter.Schema))
+	}
+	requestBody := o.Operation.Operation.RequestBody
+	bodyMediaType, hasJSONBody := (*oas.MediaTypeObject)(nil), false
+	if requestBody != nil {
+		bodyMediaType, hasJSONBody = requestBody.Content[oas.ContentTypeJson]
+		if hasJSONBody {
+			bodyType := o.goType(&bodyMediaType.Schema)
+			if o.isStruct(&bodyMediaType.Schema) {
+				bodyType = "*" + bodyType
+			}
+			arguments = append(arguments, "body "+bodyType)
+		} else {
+			arguments = append(arguments, "body io.Reader", "contentType string")
+		}
+	}
+	if len(o.otherParams) != 0 {
+		arguments = append(arguments, "params *"+o.paramsTypeName())
+	}
+
+	result := o.successResult(o.Operation.Operation)
+	errorReturn := "return err"
+	switch {
+	case result == nil:
+		fmt.Fprintf(buffer, "func (c *Client) %s(%s) error {\n", o.Name, strings.Join(arguments, ", "))
+	case result.isPointer:
+		fmt.Fprintf(buffer, "func (c *Client) %s(%s) (*%s, error) {\n", o.Name, strings.Join(arguments, ", "), result.goType)
+		errorReturn = "return nil, err"
+	default:
+		fmt.Fprintf(buffer, "func (c *Client) %s(%s) (%s, error) {\n", o.Name, strings.Join(arguments, ", "), result.goType)
+		errorReturn = "return result, err"
+	}
+	if result != nil {
+		fmt.Fprintf(buffer, "var result %s\n", result.goType)
+	}
+
+	path := o.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	fmt.Fprintf(buffer, "r := newRequest(%q, %q)\n", o.Method, path)
+	for _, parameter := range o.pathParams {
+		fmt.Fprintf(buffer, "if err := r.setParam(%q, %q, %s); err != nil {\n%s\n}\n", parameter.In, parameter.Name, LowerGoName(parameter.Name), errorReturn)
+	}
+	if o.hasRequiredParams() {
+		fmt.Fprintf(buffer, "if params == nil {\nerr := errors.New(\"%s: params are required\")\n%s\n}\n", o.Name, errorReturn)
+	}
+	if len(o.otherParams) != 0 {
+		buffer.WriteString("if params != nil {\n")
+		for _, parameter := range o.otherParams {
+			field := "params." + GoName(parameter.Name)
+			if parameter.Required {
+				fmt.Fprintf(buffer, "if err := r.setParam(%q, %q, %s); err != nil {\n%s\n}\n", parameter.In, parameter.Name, field, errorReturn)
+				continue
+			}
+			fmt.Fprintf(buffer, "if %s != nil {\nif err := r.setParam(%q, %q, *%s); err != nil {\n%s\n}\n}\n", field, parameter.In, parameter.Name, field, errorReturn)
+		}
+		buffer.WriteString("}\n")
+	}
+	if requestBody != nil {
+		if hasJSONBody {
+			buffer.WriteString("r.body = body\n")
+		} else {
+			buffer.WriteString("r.rawBody, r.contentType = body, contentType\n")
+		}
+	}
+	if result != nil {
+		buffer.WriteString("r.result = &result\n")
+	}
+	if len(o.failures()) != 0 {
+		fmt.Fprintf(buffer, "r.decodeError = decode%sError\n", o.Name)
+	}
+	buffer.WriteString("if err := c.do(ctx, r); err != nil {\n" + errorReturn + "\n}\n")
+	switch {
+	case result == nil:
+		buffer.WriteString("return nil\n}\n\n")
+	case result.isPointer:
+		buffer.WriteString("return &result, nil\n}\n\n")
+	default:
+		buffer.WriteString("return result, nil\n}\n\n")
+	}
+}
+
+const clientRuntime = `// Client calls the operations of the API
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	// RequestEditors are applied to every request before it is sent, eg. to add an Authorization header
+	RequestEditors []RequestEditor
+}
+
+// RequestEditor changes a request before it is sent
+type RequestEditor func(ctx context.Context, req *http.Request) error
+
+type Option func(client *Client)
+
+// WithHTTPClient replaces http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(client *Client) {
+		client.HTTPClient = httpClient
+	}
+}
+
+// WithRequestEditor adds a RequestEditor to the client
+func WithRequestEditor(editor RequestEditor) Option {
+	return func(client *Client) {
+		client.RequestEditors = append(client.RequestEditors, editor)
+	}
+}
+
+func NewClient(baseURL string, options ...Option) *Client {
+	client := &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: http.DefaultClient,
+	}
+	for _, option := range options {
+		option(client)
+	}
+	return client
+}
+
+// APIError is returned for a response whose status is not documented for the operation
+type APIError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	header      http.Header
+	body        interface{}
+	rawBody     io.Reader
+	contentType string
+	result      interface{}
+	decodeError func(statusCode int, body []byte) error
+}
+
+func newRequest(method, path string) *request {
+	return &request{method: method, path: path, query: url.Values{}, header: http.Header{}}
+}
+
+func (r *request) setParam(in, name string, value interface{}) error {
+	formatted, err := formatParam(value)
+	if err != nil {
+		return fmt.Errorf("can not format param %s: %v", name, err)
+	}
+	switch in {
+	case "path":
+		r.path = strings.Replace(r.path, "{"+name+"}", url.PathEscape(formatted), 1)
+	case "query":
+		r.query.Set(name, formatted)
+	case "header":
+		r.header.Set(name, formatted)
+	case "cookie":
+		r.header.Add("Cookie", (&http.Cookie{Name: name, Value: formatted}).String())
+	}
+	return nil
+}
+
+// formatParam writes basic values as text, slices of basic values as comma separated lists and
+// anything else as json
+func formatParam(value interface{}) (string, error) {
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		items := make([]string, 0, reflectValue.Len())
+		for i := 0; i < reflectValue.Len(); i++ {
+			item, err := formatParam(reflectValue.Index(i).Interface())
+			if err != nil {
+				return "", err
+			}
+			items = append(items, item)
+		}
+		return strings.Join(items, ","), nil
+	case reflect.Struct, reflect.Map, reflect.Ptr, reflect.Interface:
+		if t, ok := value.(time.Time); ok {
+			return t.Format(time.RFC3339), nil
+		}
+		b, err := json.Marshal(value)
+		return string(b), err
+	}
+	return fmt.Sprint(value), nil
+}
+
+func (c *Client) do(ctx context.Context, r *request) error {
+	body := r.rawBody
+	if r.body != nil {
+		b, err := json.Marshal(r.body)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+		r.contentType = "application/json"
+	}
+	target := c.BaseURL + r.path
+	if len(r.query) != 0 {
+		target += "?" + r.query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, r.method, target, body)
+	if err != nil {
+		return err
+	}
+	for key, values := range r.header {
+		req.Header[key] = values
+	}
+	if r.contentType != "" {
+		req.Header.Set("Content-Type", r.contentType)
+	}
+	for _, editor := range c.RequestEditors {
+		if err := editor(ctx, req); err != nil {
+			return err
+		}
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		if r.decodeError != nil {
+			return r.decodeError(resp.StatusCode, respBody)
+		}
+		return &APIError{StatusCode: resp.StatusCode, Body: respBody}
+	}
+	switch result := r.result.(type) {
+	case nil:
+		return nil
+	case *string:
+		*result = string(respBody)
+		return nil
+	}
+	if len(respBody) == 0 {
+		return nil
+	}
+	return json.Unmarshal(respBody, r.result)
+}
+`
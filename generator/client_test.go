@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/iancoleman/orderedmap"
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GenerateClient(t *testing.T) {
+	openAPI := getClientOpenAPIObject()
+	getUser := openAPI.Paths["/users/{id}"].Get
+	getUser.Parameters = append(getUser.Parameters,
+		oas.ParameterObject{Name: "X-Tenant", In: "header", Required: true, Schema: &oas.SchemaObject{Type: "string"}})
+	files, err := GenerateClient(openAPI, ClientOptions{Package: "users"})
+
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+	assert.Contains(t, string(files["models.go"]), "type User struct {\n\tID   int64  `json:\"id\"`\n\tName string `json:\"name,omitempty\"`\n}")
+	assert.Contains(t, string(files["operations.go"]), "func (c *Client) GetUser(ctx context.Context, id int64, params *GetUserParams) (*User, error) {")
+	assert.Contains(t, string(files["operations.go"]), "type GetUserNotFoundError struct {\n\tStatusCode int\n\tBody       Error\n}")
+	assert.Contains(t, string(files["operations.go"]), "func (c *Client) DeleteUsersByID(ctx context.Context, id int64) error {")
+	assert.Contains(t, string(files["operations.go"]), "if params == nil {\n\t\terr := errors.New(\"GetUser: params are required\")")
+	assertCompiles(t, files)
+}
+
+// assertCompiles type checks the generated files as one package
+func assertCompiles(t *testing.T, files Files) {
+	t.Helper()
+	fileSet := token.NewFileSet()
+	var astFiles []*ast.File
+	for name, source := range files {
+		file, err := parser.ParseFile(fileSet, name, source, 0)
+		if !assert.NoError(t, err) {
+			return
+		}
+		astFiles = append(astFiles, file)
+	}
+	config := types.Config{Importer: importer.ForCompiler(fileSet, "source", nil)}
+	_, err := config.Check("generated", fileSet, astFiles, nil)
+	assert.NoError(t, err)
+}
+
+func getClientOpenAPIObject() *oas.OpenAPIObject {
+	userProperties := orderedmap.New()
+	userProperties.Set("id", &oas.SchemaObject{Type: "integer"})
+	userProperties.Set("name", &oas.SchemaObject{Type: "string"})
+	errorProperties := orderedmap.New()
+	errorProperties.Set("code", &oas.SchemaObject{Type: "string"})
+	idParameter := oas.ParameterObject{Name: "id", In: "path", Required: true, Schema: &oas.SchemaObject{Type: "integer"}}
+
+	return &oas.OpenAPIObject{
+		Paths: oas.PathsObject{
+			"/users/{id}": {
+				Get: &oas.OperationObject{
+					OperationID: "GetUser",
+					Parameters: []oas.ParameterObject{
+						idParameter,
+						{Name: "fields", In: "query", Schema: &oas.SchemaObject{Type: "array", Items: &oas.SchemaObject{Type: "string"}}},
+					},
+					Responses: oas.ResponsesObject{
+						"200": {Content: map[string]*oas.MediaTypeObject{
+							oas.ContentTypeJson: {Schema: oas.SchemaObject{Ref: "#/components/schemas/model.User"}},
+						}},
+						"404": {Content: map[string]*oas.MediaTypeObject{
+							oas.ContentTypeJson: {Schema: oas.SchemaObject{Ref: "#/components/schemas/model.Error"}},
+						}},
+					},
+				},
+				Delete: &oas.OperationObject{
+					Parameters: []oas.ParameterObject{idParameter},
+					Responses:  oas.ResponsesObject{"204": {Description: "Deleted"}},
+				},
+			},
+		},
+		Components: oas.ComponentsObject{
+			Schemas: map[string]*oas.SchemaObject{
+				"model.User":  {Type: "object", Properties: userProperties, Required: []string{"id"}},
+				"model.Error": {Type: "object", Properties: errorProperties},
+			},
+		},
+	}
+}
+
+func Test_ImportOriginalTypesSkipsEmptyTypeName(t *testing.T) {
+	openAPI := &oas.OpenAPIObject{Components: oas.ComponentsObject{Schemas: map[string]*oas.SchemaObject{
+		"example.com/store.": {Type: "object", PkgName: "example.com/store"},
+	}}}
+
+	assert.Empty(t, importOriginalTypes(openAPI, newTypeMapper(openAPI)))
+}
@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"strings"
+	"unicode"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/transform"
+)
+
+// GoName turns a json name, a path or an operation id into an exported go identifier,
+// eg. "user_id" -> "UserID", "Client-Version" -> "ClientVersion"
+func GoName(name string) string {
+	return transform.GoName(name)
+}
+
+// LowerGoName is GoName for unexported identifiers and function arguments
+func LowerGoName(name string) string {
+	goName := GoName(name)
+	runes := []rune(goName)
+	i := 0
+	for i < len(runes) && unicode.IsUpper(runes[i]) {
+		i++
+	}
+	switch {
+	case i == len(runes):
+		goName = strings.ToLower(goName)
+	case i > 1:
+		goName = strings.ToLower(string(runes[:i-1])) + string(runes[i-1:])
+	default:
+		goName = strings.ToLower(string(runes[:1])) + string(runes[1:])
+	}
+	if isReservedName(goName) {
+		return goName + "Param"
+	}
+	return goName
+}
+
+func isReservedName(name string) bool {
+	switch name {
+	case "break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough", "for",
+		"func", "go", "goto", "if", "import", "interface", "map", "package", "range", "return", "select",
+		"struct", "switch", "type", "var",
+		// names used by the generated code
+		"ctx", "params", "body", "path", "query", "header", "result", "err":
+		return true
+	}
+	return false
+}
+
+// ComponentTypeNames maps every component schema to the go type generated for it, see
+// transform.ComponentTypeNames
+func ComponentTypeNames(openAPI *oas.OpenAPIObject) map[string]string {
+	return transform.ComponentTypeNames(openAPI)
+}
+
+// Operation is an operation of the spec together with its route and a unique go name
+type Operation = transform.Operation
+
+// Operations lists the operations of the spec sorted by path and method, see transform.Operations
+func Operations(openAPI *oas.OpenAPIObject) []Operation {
+	return transform.Operations(openAPI)
+}
@@ -0,0 +1,463 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/transform"
+)
+
+const (
+	RouterNetHTTP = "nethttp"
+	RouterChi     = "chi"
+)
+
+const chiImportPath = "github.com/go-chi/chi/v5"
+
+type ServerOptions struct {
+	Package string
+	// ModelsImportPath is the import path of the generated model package
+	ModelsImportPath string
+	// Router is the router the generated handlers are registered to, nethttp or chi
+	Router string
+}
+
+// GenerateServer generates the server side of the spec: a Server interface with one method per
+// operation, a router decoding the params and dispatching to the Server, and the models. The
+// handlers and the models carry the annotations and struct tags the spec is parsed back from.
+func GenerateServer(openAPI *oas.OpenAPIObject, options ServerOptions) (Files, error) {
+	if options.Package == "" {
+		options.Package = "api"
+	}
+	if options.Router == "" {
+		options.Router = RouterNetHTTP
+	}
+	if options.Router != RouterNetHTTP && options.Router != RouterChi {
+		return nil, fmt.Errorf("unknown router %s, expected %s or %s", options.Router, RouterNetHTTP, RouterChi)
+	}
+	if options.ModelsImportPath == "" {
+		return nil, fmt.Errorf("the import path of the model package is required")
+	}
+
+	typeNames := ComponentTypeNames(openAPI)
+	operations := Operations(openAPI)
+	files := Files{}
+	var err error
+
+	var headerTypes map[string]string
+	files["model/models.go"], headerTypes, err = generateTaggedModels(openAPI, typeNames, operations)
+	if err != nil {
+		return nil, err
+	}
+
+	mapper := newTypeMapper(openAPI)
+	modelAlias := mapper.importPackage(options.ModelsImportPath)
+	for key, typeName := range typeNames {
+		mapper.names[key] = modelAlias + "." + typeName
+		if schema := openAPI.Components.Schemas[key]; transform.IsEnumSchema(schema) {
+			mapper.names[key] = mapper.enumType(schema)
+		}
+	}
+	annotations := annotationWriter{openAPI: openAPI, qualifier: modelAlias + ".", typeNames: typeNames, headerTypes: headerTypes}
+	files["doc.go"], err = generateDoc(options.Package, annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	serverOperations := make([]serverOperation, 0, len(operations))
+	for _, operation := range operations {
+		serverOperations = append(serverOperations, newServerOperation(mapper, operation))
+	}
+
+	var server bytes.Buffer
+	server.WriteString("// Server is implemented by the service, with one method per operation of the API\ntype Server interface {\n")
+	for _, operation := range serverOperations {
+		operation.writeInterfaceMethod(&server)
+	}
+	server.WriteString("}\n\n")
+	for _, operation := range serverOperations {
+		operation.writeParamsStruct(&server)
+	}
+	server.WriteString(serverRuntime)
+	files["server.go"], err = formatSource(options.Package, server.Bytes(), mapper.imports)
+	if err != nil {
+		return nil, err
+	}
+
+	var router bytes.Buffer
+	if options.Router == RouterChi {
+		mapper.imports[chiImportPath] = "chi"
+		router.WriteString(chiRouter)
+	} else {
+		router.WriteString(netHTTPRouter)
+	}
+	router.WriteString("func registerRoutes(h *handler, handle func(method, pattern string, handlerFunc http.HandlerFunc)) {\n")
+	for _, operation := range serverOperations {
+		fmt.Fprintf(&router, "handle(%q, %q, h.%s)\n", operation.Method, operation.routePattern(), operation.handlerName())
+	}
+	router.WriteString("}\n\n")
+	for _, operation := range serverOperations {
+		annotations.writeOperation(&router, operation.Operation)
+		operation.writeHandler(&router)
+	}
+	router.WriteString(routerRuntime)
+	files["router.go"], err = formatSource(options.Package, router.Bytes(), mapper.imports)
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+type serverOperation struct {
+	*typeMapper
+	Operation
+
+	pathParams  []*oas.ParameterObject
+	otherParams []*oas.ParameterObject
+	result      *result
+}
+
+func newServerOperation(mapper *typeMapper, operation Operation) serverOperation {
+	o := serverOperation{typeMapper: mapper, Operation: operation, result: mapper.successResult(operation.Operation)}
+	for i := range operation.Operation.Parameters {
+		parameter := mapper.openAPI.ResolveParameter(&operation.Operation.Parameters[i])
+		if parameter.In == "path" {
+			o.pathParams = append(o.pathParams, parameter)
+		} else if parameter.Name != "" {
+			o.otherParams = append(o.otherParams, parameter)
+		}
+	}
+	return o
+}
+
+func (o serverOperation) paramsTypeName() string {
+	return o.Name + "Params"
+}
+
+func (o serverOperation) handlerName() string {
+	return LowerGoName(o.Name)
+}
+
+// routePattern is the path with every param renamed to a go identifier, the router patterns do
+// not allow other names
+func (o serverOperation) routePattern() string {
+	pattern := o.Path
+	for _, parameter := range o.pathParams {
+		pattern = strings.ReplaceAll(pattern, "{"+parameter.Name+"}", "{"+LowerGoName(parameter.Name)+"}")
+	}
+	if !strings.HasPrefix(pattern, "/") {
+		pattern = "/" + pattern
+	}
+	return pattern
+}
+
+// bodyType is the go type of the request body argument, empty when the operation has no body
+func (o serverOperation) bodyType() (goType string, isForm bool) {
+	requestBody := o.Operation.Operation.RequestBody
+	if requestBody == nil {
+		return "", false
+	}
+	if mediaType, ok := requestBody.Content[oas.ContentTypeJson]; ok {
+		return o.goType(&mediaType.Schema), false
+	}
+	return "*multipart.Form", true
+}
+
+func (o serverOperation) arguments() []string {
+	arguments := []string{"ctx context.Context"}
+	for _, parameter := range o.pathParams {
+		arguments = append(arguments, LowerGoName(parameter.Name)+" "+o.goType(parameter.Schema))
+	}
+	if bodyType, isForm := o.bodyType(); isForm {
+		arguments = append(arguments, "form "+bodyType)
+	} else if bodyType != "" {
+		if mediaType := o.Operation.Operation.RequestBody.Content[oas.ContentTypeJson]; o.isStruct(&mediaType.Schema) {
+			bodyType = "*" + bodyType
+		}
+		arguments = append(arguments, "body "+bodyType)
+	}
+	if len(o.otherParams) != 0 {
+		arguments = append(arguments, "params "+o.paramsTypeName())
+	}
+	return arguments
+}
+
+func (o serverOperation) writeInterfaceMethod(buffer *bytes.Buffer) {
+	summary := o.Operation.Operation.Summary
+	if summary == "" {
+		summary = fmt.Sprintf("handles %s %s", o.Method, o.Path)
+	}
+	writeComment(buffer, o.Name, summary)
+	arguments := strings.Join(o.arguments(), ", ")
+	switch {
+	case o.result == nil:
+		fmt.Fprintf(buffer, "%s(%s) error\n", o.Name, arguments)
+	case o.result.isPointer:
+		fmt.Fprintf(buffer, "%s(%s) (*%s, error)\n", o.Name, arguments, o.result.goType)
+	default:
+		fmt.Fprintf(buffer, "%s(%s) (%s, error)\n", o.Name, arguments, o.result.goType)
+	}
+}
+
+func (o serverOperation) writeParamsStruct(buffer *bytes.Buffer) {
+	if len(o.otherParams) == 0 {
+		return
+	}
+	fmt.Fprintf(buffer, "// %s are the query, header and cookie params of %s\n", o.paramsTypeName(), o.Name)
+	fmt.Fprintf(buffer, "type %s struct {\n", o.paramsTypeName())
+	for _, parameter := range o.otherParams {
+		writeComment(buffer, GoName(parameter.Name), parameter.Description)
+		goType := o.goType(parameter.Schema)
+		if !parameter.Required {
+			goType = "*" + goType
+		}
+		fmt.Fprintf(buffer, "%s %s\n", GoName(parameter.Name), goType)
+	}
+	buffer.WriteString("}\n\n")
+}
+
+// successStatus is the status written when the Server method succeeds
+func (o serverOperation) successStatus() int {
+	var statuses []string
+	for status := range o.Operation.Operation.Responses {
+		if strings.HasPrefix(status, "2") {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Strings(statuses)
+	if len(statuses) != 0 {
+		if status, err := strconv.Atoi(statuses[0]); err == nil {
+			return status
+		}
+	}
+	return http.StatusOK
+}
+
+func (o serverOperation) writeHandler(buffer *bytes.Buffer) {
+	fmt.Fprintf(buffer, "func (h *handler) %s(w http.ResponseWriter, r *http.Request) {\n", o.handlerName())
+	decode := func(values, name string, required bool, target string) {
+		fmt.Fprintf(buffer, "if err := decodeParam(%s, %q, %t, &%s); err != nil {\nwriteError(w, err)\nreturn\n}\n",
+			values, name, required, target)
+	}
+
+	callArguments := []string{"r.Context()"}
+	for _, parameter := range o.pathParams {
+		variable := LowerGoName(parameter.Name)
+		fmt.Fprintf(buffer, "var %s %s\n", variable, o.goType(parameter.Schema))
+		decode(fmt.Sprintf("[]string{pathParam(r, %q)}", LowerGoName(parameter.Name)), parameter.Name, true, variable)
+		callArguments = append(callArguments, variable)
+	}
+
+	if bodyType, isForm := o.bodyType(); isForm {
+		buffer.WriteString("if err := r.ParseMultipartForm(maxMemory); err != nil {\nwriteError(w, badRequest(err))\nreturn\n}\n")
+		callArguments = append(callArguments, "r.MultipartForm")
+	} else if bodyType != "" {
+		fmt.Fprintf(buffer, "var body %s\n", bodyType)
+		fmt.Fprintf(buffer, "if err := decodeBody(r, %t, &body); err != nil {\nwriteError(w, err)\nreturn\n}\n",
+			o.Operation.Operation.RequestBody.Required)
+		if mediaType := o.Operation.Operation.RequestBody.Content[oas.ContentTypeJson]; o.isStruct(&mediaType.Schema) {
+			callArguments = append(callArguments, "&body")
+		} else {
+			callArguments = append(callArguments, "body")
+		}
+	}
+
+	if len(o.otherParams) != 0 {
+		fmt.Fprintf(buffer, "var params %s\n", o.paramsTypeName())
+		for _, parameter := range o.otherParams {
+			var values string
+			switch parameter.In {
+			case "header":
+				values = fmt.Sprintf("r.Header.Values(%q)", parameter.Name)
+			case "cookie":
+				values = fmt.Sprintf("cookieValues(r, %q)", parameter.Name)
+			default:
+				values = fmt.Sprintf("r.URL.Query()[%q]", parameter.Name)
+			}
+			decode(values, parameter.Name, parameter.Required, "params."+GoName(parameter.Name))
+		}
+		callArguments = append(callArguments, "params")
+	}
+
+	call := fmt.Sprintf("h.server.%s(%s)", o.Name, strings.Join(callArguments, ", "))
+	switch {
+	case o.result == nil:
+		fmt.Fprintf(buffer, "if err := %s; err != nil {\nwriteError(w, err)\nreturn\n}\n", call)
+		fmt.Fprintf(buffer, "w.WriteHeader(%d)\n}\n\n", o.successStatus())
+	case o.result.isText:
+		fmt.Fprintf(buffer, "result, err := %s\nif err != nil {\nwriteError(w, err)\nreturn\n}\n", call)
+		fmt.Fprintf(buffer, "writeText(w, %d, result)\n}\n\n", o.successStatus())
+	default:
+		fmt.Fprintf(buffer, "result, err := %s\nif err != nil {\nwriteError(w, err)\nreturn\n}\n", call)
+		fmt.Fprintf(buffer, "writeJSON(w, %d, result)\n}\n\n", o.successStatus())
+	}
+}
+
+const serverRuntime = `// HTTPError is returned by a Server method to answer with another status than the success one,
+// eg. one of the documented failures. A nil Body writes no content.
+type HTTPError struct {
+	StatusCode int
+	Body       interface{}
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+`
+
+const netHTTPRouter = `// NewRouter routes the operations of the API to the server.
+// It relies on the method and wildcard patterns of http.ServeMux, available since go 1.22.
+func NewRouter(server Server) http.Handler {
+	mux := http.NewServeMux()
+	registerRoutes(&handler{server: server}, func(method, pattern string, handlerFunc http.HandlerFunc) {
+		mux.HandleFunc(method+" "+pattern, handlerFunc)
+	})
+	return mux
+}
+
+func pathParam(r *http.Request, name string) string {
+	return r.PathValue(name)
+}
+
+`
+
+const chiRouter = `// NewRouter routes the operations of the API to the server
+func NewRouter(server Server) http.Handler {
+	router := chi.NewRouter()
+	registerRoutes(&handler{server: server}, func(method, pattern string, handlerFunc http.HandlerFunc) {
+		router.MethodFunc(method, pattern, handlerFunc)
+	})
+	return router
+}
+
+func pathParam(r *http.Request, name string) string {
+	return chi.URLParam(r, name)
+}
+
+`
+
+const routerRuntime = `// maxMemory is the part of a multipart form kept in memory, the rest is stored in temporary files
+const maxMemory = 32 << 20
+
+type handler struct {
+	server Server
+}
+
+// decodeParam parses the values of a param into target, a pointer to the variable or the field
+func decodeParam(values []string, name string, required bool, target interface{}) error {
+	if len(values) == 0 || values[0] == "" {
+		if required {
+			return badRequest(fmt.Errorf("param %s is required", name))
+		}
+		return nil
+	}
+	value := reflect.ValueOf(target).Elem()
+	if value.Kind() == reflect.Ptr {
+		value.Set(reflect.New(value.Type().Elem()))
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.Slice && value.Type().Elem().Kind() != reflect.Uint8 {
+		if len(values) == 1 {
+			values = strings.Split(values[0], ",")
+		}
+		slice := reflect.MakeSlice(value.Type(), len(values), len(values))
+		for i := range values {
+			if err := setValue(slice.Index(i), values[i]); err != nil {
+				return badRequest(fmt.Errorf("param %s: %v", name, err))
+			}
+		}
+		value.Set(slice)
+		return nil
+	}
+	if err := setValue(value, values[0]); err != nil {
+		return badRequest(fmt.Errorf("param %s: %v", name, err))
+	}
+	return nil
+}
+
+func setValue(value reflect.Value, raw string) error {
+	if unmarshaler, ok := value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(raw))
+	}
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+	case reflect.Slice:
+		value.SetBytes([]byte(raw))
+	default:
+		// objects are passed as json
+		return json.Unmarshal([]byte(raw), value.Addr().Interface())
+	}
+	return nil
+}
+
+func cookieValues(r *http.Request, name string) []string {
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return nil
+	}
+	return []string{cookie.Value}
+}
+
+func decodeBody(r *http.Request, required bool, target interface{}) error {
+	err := json.NewDecoder(r.Body).Decode(target)
+	if err == io.EOF && !required {
+		return nil
+	}
+	if err != nil {
+		return badRequest(fmt.Errorf("invalid request body: %v", err))
+	}
+	return nil
+}
+
+func badRequest(err error) error {
+	return &HTTPError{StatusCode: http.StatusBadRequest, Body: map[string]string{"message": err.Error()}}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		httpErr = &HTTPError{StatusCode: http.StatusInternalServerError, Body: map[string]string{"message": err.Error()}}
+	}
+	if httpErr.Body == nil {
+		w.WriteHeader(httpErr.StatusCode)
+		return
+	}
+	writeJSON(w, httpErr.StatusCode, httpErr.Body)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeText(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, body)
+}
+`
@@ -0,0 +1,26 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GenerateServer(t *testing.T) {
+	files, err := GenerateServer(getClientOpenAPIObject(), ServerOptions{Package: "users", ModelsImportPath: "example.com/users/model"})
+
+	assert.NoError(t, err)
+	assert.Len(t, files, 4)
+	assert.Contains(t, string(files["model/models.go"]), "type User struct {\n\tID   int64  `json:\"id\" required:\"true\"`\n\tName string `json:\"name,omitempty\"`\n}")
+	assert.Contains(t, string(files["server.go"]), "GetUser(ctx context.Context, id int64, params GetUserParams) (*model.User, error)")
+	assert.Contains(t, string(files["server.go"]), "DeleteUsersByID(ctx context.Context, id int64) error")
+	assert.Contains(t, string(files["router.go"]), "handle(\"GET\", \"/users/{id}\", h.getUser)")
+	assert.Contains(t, string(files["router.go"]), "// @Param id path int64 true \"id\"\n// @Param fields query []string false \"fields\"\n"+
+		"// @Success 200 {object} model.User \"\"\n// @Failure 404 {object} model.Error \"\"\n// @OperationId GetUser\n// @Route /users/{id} [get]\n")
+}
+
+func Test_GenerateServer_UnknownRouter(t *testing.T) {
+	_, err := GenerateServer(getClientOpenAPIObject(), ServerOptions{ModelsImportPath: "example.com/users/model", Router: "gin"})
+
+	assert.EqualError(t, err, "unknown router gin, expected nethttp or chi")
+}
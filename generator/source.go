@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	goParser "go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const generatedHeader = "// Code generated by go-swagger3. DO NOT EDIT.\n\n"
+
+// Files are generated source files by file name
+type Files map[string][]byte
+
+// Write creates the directory and writes every file into it
+func (f Files) Write(dir string) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("Can not create the directory %s: %v", dir, err)
+	}
+	for name, content := range f {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			return fmt.Errorf("Can not create the directory %s: %v", filepath.Dir(path), err)
+		}
+		if err := ioutil.WriteFile(path, content, 0644); err != nil {
+			return fmt.Errorf("Can not write the file %s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+var standardImports = map[string]string{
+	"bytes":     "bytes",
+	"context":   "context",
+	"encoding":  "encoding",
+	"errors":    "errors",
+	"fmt":       "fmt",
+	"io":        "io",
+	"ioutil":    "io/ioutil",
+	"json":      "encoding/json",
+	"multipart": "mime/multipart",
+	"http":      "net/http",
+	"reflect":   "reflect",
+	"strconv":   "strconv",
+	"strings":   "strings",
+	"time":      "time",
+	"url":       "net/url",
+}
+
+// formatSource adds the header, the package clause and the imports the body refers to, then gofmts
+// the file. Packages are looked up in the standard imports and in imports (import path -> alias),
+// the imports aliased "_" are always added.
+func formatSource(packageName string, body []byte, imports map[string]string) ([]byte, error) {
+	aliases := map[string]string{}
+	for alias, importPath := range standardImports {
+		aliases[alias] = importPath
+	}
+	var blankImports []string
+	for importPath, alias := range imports {
+		if alias == "_" {
+			blankImports = append(blankImports, importPath)
+			continue
+		}
+		aliases[alias] = importPath
+	}
+
+	source := append([]byte("package "+packageName+"\n\n"), body...)
+	file, err := goParser.ParseFile(token.NewFileSet(), "", source, 0)
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid go code: %v\n%s", err, source)
+	}
+	used := map[string]bool{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		selector, ok := node.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if ident, ok := selector.X.(*ast.Ident); ok && ident.Obj == nil {
+			if _, known := aliases[ident.Name]; known {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	var buffer bytes.Buffer
+	buffer.WriteString(generatedHeader)
+	buffer.WriteString("package " + packageName + "\n\n")
+	if len(used) != 0 || len(blankImports) != 0 {
+		var standardLines, otherLines []string
+		type packageImport struct{ alias, importPath string }
+		var packageImports []packageImport
+		for alias := range used {
+			packageImports = append(packageImports, packageImport{alias, aliases[alias]})
+		}
+		for _, importPath := range blankImports {
+			packageImports = append(packageImports, packageImport{"_", importPath})
+		}
+		for _, packageImport := range packageImports {
+			alias, importPath := packageImport.alias, packageImport.importPath
+			line := strconv.Quote(importPath)
+			if filepath.Base(importPath) != alias {
+				line = alias + " " + line
+			}
+			if strings.Contains(strings.Split(importPath, "/")[0], ".") {
+				otherLines = append(otherLines, line)
+			} else {
+				standardLines = append(standardLines, line)
+			}
+		}
+		buffer.WriteString("import (\n")
+		for i, lines := range [][]string{standardLines, otherLines} {
+			if i == 1 && len(standardLines) != 0 && len(otherLines) != 0 {
+				buffer.WriteString("\n")
+			}
+			sort.Slice(lines, func(i, j int) bool { return unaliased(lines[i]) < unaliased(lines[j]) })
+			for _, line := range lines {
+				buffer.WriteString(line + "\n")
+			}
+		}
+		buffer.WriteString(")\n\n")
+	}
+	buffer.Write(body)
+
+	formatted, err := format.Source(buffer.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid go code: %v\n%s", err, buffer.Bytes())
+	}
+	return formatted, nil
+}
+
+func unaliased(importLine string) string {
+	for i := range importLine {
+		if importLine[i] == '"' {
+			return importLine[i:]
+		}
+	}
+	return importLine
+}
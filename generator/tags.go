@@ -0,0 +1,309 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/transform"
+)
+
+// schemaTags builds the struct tag of a property with every keyword the schema parser reads back
+func schemaTags(openAPI *oas.OpenAPIObject, jsonName string, property *oas.SchemaObject, required bool) string {
+	var tags []string
+	add := func(key, value string) {
+		tags = append(tags, key+":"+strconv.Quote(strings.ReplaceAll(value, "`", "'")))
+	}
+	if required {
+		add("json", jsonName)
+	} else {
+		add("json", jsonName+",omitempty")
+	}
+	if property.Ref != "" && transform.IsEnumSchema(openAPI.ResolveSchema(property)) {
+		add("$ref", strings.TrimPrefix(property.Ref, oas.SchemaRefPrefix))
+	}
+	if property.Format != "" && property.Format != "date-time" {
+		add("format", property.Format)
+	}
+	if property.Description != "" {
+		add("description", property.Description)
+	}
+	if property.Example != nil {
+		add("example", exampleTagValue(property.Example))
+	}
+	if property.Default != nil {
+		add("default", exampleTagValue(property.Default))
+	}
+	if enum, ok := property.Enum.([]interface{}); ok && len(enum) == 1 {
+		add("const", exampleTagValue(enum[0]))
+	} else if ok && len(enum) != 0 {
+		add("enum", joinValues(enum))
+	}
+	if property.Not != nil {
+		if enum, ok := property.Not.Enum.([]interface{}); ok && len(enum) != 0 && reflect.DeepEqual(*property.Not, oas.SchemaObject{Enum: enum}) {
+			add("not", joinValues(enum))
+		}
+	}
+	if property.Title != "" {
+		add("title", property.Title)
+	}
+	if property.Maximum != 0 {
+		add("maximum", strconv.FormatFloat(property.Maximum, 'f', -1, 64))
+	}
+	if property.ExclusiveMaximum {
+		add("exclusiveMaximum", "true")
+	}
+	if property.Minimum != 0 {
+		add("minimum", strconv.FormatFloat(property.Minimum, 'f', -1, 64))
+	}
+	if property.ExclusiveMinimum {
+		add("exclusiveMinimum", "true")
+	}
+	if property.MultipleOf != 0 {
+		add("multipleOf", strconv.FormatFloat(property.MultipleOf, 'f', -1, 64))
+	}
+	if property.MaxLength != 0 {
+		add("maxLength", strconv.FormatUint(uint64(property.MaxLength), 10))
+	}
+	if property.MinLength != 0 {
+		add("minLength", strconv.FormatUint(uint64(property.MinLength), 10))
+	}
+	if property.Pattern != "" {
+		add("pattern", property.Pattern)
+	}
+	if property.MaxItems != 0 {
+		add("maxItems", strconv.FormatUint(uint64(property.MaxItems), 10))
+	}
+	if property.MinItems != 0 {
+		add("minItems", strconv.FormatUint(uint64(property.MinItems), 10))
+	}
+	if property.UniqueItems {
+		add("uniqueItems", "true")
+	}
+	if property.MaxProperties != 0 {
+		add("maxProperties", strconv.FormatUint(uint64(property.MaxProperties), 10))
+	}
+	if property.MinProperties != 0 {
+		add("minProperties", strconv.FormatUint(uint64(property.MinProperties), 10))
+	}
+	if property.AdditionalProperties != nil && property.AdditionalProperties.Schema == nil {
+		add("additionalProperties", strconv.FormatBool(property.AdditionalProperties.Allowed))
+	}
+	if property.Nullable {
+		add("nullable", "true")
+	}
+	if property.ReadOnly {
+		add("readOnly", "true")
+	}
+	if property.WriteOnly {
+		add("writeOnly", "true")
+	}
+	if required {
+		add("required", "true")
+	}
+	return strings.Join(tags, " ")
+}
+
+// exampleTagValue writes an example the way the `example` tag is parsed: text as it is,
+// everything else as json
+func exampleTagValue(example interface{}) string {
+	if text, ok := example.(string); ok {
+		return text
+	}
+	b, err := json.Marshal(example)
+	if err != nil {
+		return fmt.Sprint(example)
+	}
+	return string(b)
+}
+
+// joinValues writes the values of an enum the way the enum and not tags list them
+func joinValues(values []interface{}) string {
+	texts := make([]string, 0, len(values))
+	for _, value := range values {
+		texts = append(texts, fmt.Sprint(value))
+	}
+	return strings.Join(texts, ",")
+}
+
+// newTaggedModelMapper maps the schemas to the annotated models the parser reads back. Enum
+// components are declared as @Enum structs, so the properties referencing them keep the basic
+// type and point to the enum with the `$ref` tag.
+func newTaggedModelMapper(openAPI *oas.OpenAPIObject, typeNames map[string]string) *typeMapper {
+	mapper := newTypeMapper(openAPI)
+	for key, typeName := range typeNames {
+		mapper.names[key] = typeName
+	}
+	for key, schema := range openAPI.Components.Schemas {
+		if transform.IsEnumSchema(schema) {
+			mapper.names[key] = mapper.enumType(schema)
+		}
+	}
+	mapper.fieldTags = func(jsonName string, property *oas.SchemaObject, required bool) string {
+		return schemaTags(openAPI, jsonName, property, required)
+	}
+	return mapper
+}
+
+// enumType is the basic go type of the values of an enum
+func (m *typeMapper) enumType(schema *oas.SchemaObject) string {
+	return m.goType(&oas.SchemaObject{Type: schema.Type, Format: schema.Format})
+}
+
+// writeTaggedModels writes every component schema as an annotated go type
+func (m *typeMapper) writeTaggedModels(buffer *bytes.Buffer, typeNames map[string]string, skip map[string]bool) {
+	keys := make([]string, 0, len(typeNames))
+	for key := range typeNames {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return typeNames[keys[i]] < typeNames[keys[j]] })
+
+	for _, key := range keys {
+		if skip[key] {
+			continue
+		}
+		schema := m.openAPI.Components.Schemas[key]
+		typeName := typeNames[key]
+		writeComment(buffer, typeName, schema.Description)
+		if name := transform.LastSegment(key); name != typeName && GoName(name) == typeName {
+			// the schema keeps its name when it is not a go type name, eg. an unexported one
+			fmt.Fprintf(buffer, "// @SchemaName %s\n", name)
+		}
+		if !transform.IsEnumSchema(schema) {
+			model := *schema
+			model.Description = ""
+			m.writeModel(buffer, typeName, &model)
+			continue
+		}
+		fmt.Fprintf(buffer, "// @Enum %s\ntype %s struct {\n", typeName, typeName)
+		fmt.Fprintf(buffer, "%s %s `%s`\n}\n\n", typeName, m.names[key], schemaTags(m.openAPI, key, schema, false))
+	}
+}
+
+// writeHeaderModels writes the header parameters of the components as a @HeaderParameters struct,
+// and a struct for every other list of header parameters an operation refers to. It returns the
+// struct of every list by headerSetKey, and the component schema the @HeaderParameters struct was
+// parsed from, if any, which then is written here instead of with the other models.
+func (m *typeMapper) writeHeaderModels(buffer *bytes.Buffer, operations []Operation, typeNames map[string]string) (map[string]string, string) {
+	parameters := m.openAPI.Components.Parameters
+	if len(parameters) == 0 {
+		return nil, ""
+	}
+	declared := map[string]bool{}
+	for _, typeName := range typeNames {
+		declared[typeName] = true
+	}
+	unusedTypeName := func(typeName string) string {
+		name := typeName
+		for i := 2; declared[name]; i++ {
+			name = typeName + strconv.Itoa(i)
+		}
+		declared[name] = true
+		return name
+	}
+
+	type headerSet struct {
+		owner string
+		names []string
+	}
+	var all []string
+	var sets []headerSet
+	seen := map[string]bool{}
+	for _, operation := range operations {
+		var names []string
+		for _, parameter := range operation.Operation.Parameters {
+			name := strings.TrimPrefix(parameter.Ref, oas.ParameterRefPrefix)
+			if _, ok := parameters[name]; parameter.Ref == "" || !ok {
+				continue
+			}
+			names = append(names, name)
+			if !seen[name] {
+				seen[name] = true
+				all = append(all, name)
+			}
+		}
+		if len(names) != 0 {
+			sets = append(sets, headerSet{owner: operation.Name, names: names})
+		}
+	}
+	var rest []string
+	for name := range parameters {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	all = append(all, rest...)
+
+	headerTypes := map[string]string{}
+	componentKey := m.headerComponent(all)
+	var typeName string
+	if componentKey != "" {
+		typeName = typeNames[componentKey]
+		all = m.openAPI.Components.Schemas[componentKey].Properties.Keys()
+	} else {
+		typeName = unusedTypeName("HeaderParameters")
+	}
+	headerTypes[headerSetKey(all)] = typeName
+	fmt.Fprintf(buffer, "// %s are the header parameters of the API\n// @HeaderParameters %s\n", typeName, typeName)
+	m.writeHeaderStruct(buffer, typeName, all)
+
+	for _, set := range sets {
+		if _, ok := headerTypes[headerSetKey(set.names)]; ok {
+			continue
+		}
+		typeName := unusedTypeName(set.owner + "Headers")
+		headerTypes[headerSetKey(set.names)] = typeName
+		fmt.Fprintf(buffer, "// %s are the header parameters of %s\n", typeName, set.owner)
+		m.writeHeaderStruct(buffer, typeName, set.names)
+	}
+	return headerTypes, componentKey
+}
+
+// headerComponent finds the component schema with exactly the header parameters as properties
+func (m *typeMapper) headerComponent(names []string) string {
+	keys := make([]string, 0, len(m.openAPI.Components.Schemas))
+	for key := range m.openAPI.Components.Schemas {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		schema := m.openAPI.Components.Schemas[key]
+		if schema.Properties == nil || len(schema.Properties.Keys()) != len(names) {
+			continue
+		}
+		matches := true
+		for _, name := range names {
+			if _, ok := schema.Properties.Get(name); !ok {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return key
+		}
+	}
+	return ""
+}
+
+func (m *typeMapper) writeHeaderStruct(buffer *bytes.Buffer, typeName string, names []string) {
+	fmt.Fprintf(buffer, "type %s struct {\n", typeName)
+	for _, name := range names {
+		parameter := m.openAPI.Components.Parameters[name]
+		schema := oas.SchemaObject{}
+		if parameter.Schema != nil {
+			schema = *parameter.Schema
+		}
+		schema.Description = parameter.Description
+		if schema.Example == nil {
+			schema.Example = parameter.Example
+		}
+		fmt.Fprintf(buffer, "%s %s `%s`\n", GoName(name), m.goType(&schema), schemaTags(m.openAPI, name, &schema, parameter.Required))
+	}
+	buffer.WriteString("}\n\n")
+}
@@ -0,0 +1,252 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/transform"
+)
+
+// typeMapper translates schemas into go type expressions
+type typeMapper struct {
+	openAPI *oas.OpenAPIObject
+	// names is the go type of every component schema
+	names map[string]string
+	// imports is the alias of every imported package, by import path
+	imports map[string]string
+	// fieldTags builds the struct tag of a property
+	fieldTags func(jsonName string, property *oas.SchemaObject, required bool) string
+	// components is the key of every component schema by its json, an inline schema identical to
+	// a component uses its type
+	components map[string]string
+}
+
+func newTypeMapper(openAPI *oas.OpenAPIObject) *typeMapper {
+	return &typeMapper{
+		openAPI:    openAPI,
+		names:      ComponentTypeNames(openAPI),
+		imports:    map[string]string{},
+		fieldTags:  jsonTag,
+		components: transform.ComponentsByJSON(openAPI),
+	}
+}
+
+// importPackage registers an import and returns the alias the generated code uses for it
+func (m *typeMapper) importPackage(importPath string) string {
+	if alias, ok := m.imports[importPath]; ok {
+		return alias
+	}
+	base := LowerGoName(path.Base(importPath))
+	alias := base
+	for i := 2; m.isAliasUsed(alias); i++ {
+		alias = base + strconv.Itoa(i)
+	}
+	m.imports[importPath] = alias
+	return alias
+}
+
+func (m *typeMapper) isAliasUsed(alias string) bool {
+	for _, used := range m.imports {
+		if used == alias {
+			return true
+		}
+	}
+	return false
+}
+
+// refTypeName returns the go type of a component reference
+func (m *typeMapper) refTypeName(ref string) (string, bool) {
+	name, ok := m.names[strings.TrimPrefix(ref, oas.SchemaRefPrefix)]
+	return name, ok
+}
+
+// goType returns the go type expression of a schema
+func (m *typeMapper) goType(schema *oas.SchemaObject) string {
+	if schema == nil {
+		return "interface{}"
+	}
+	if schema.Ref != "" {
+		if name, ok := m.refTypeName(schema.Ref); ok {
+			return name
+		}
+		return "interface{}"
+	}
+	switch schema.Type {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			m.importPackage("time")
+			return "time.Time"
+		case "binary", "byte":
+			return "[]byte"
+		}
+		return "string"
+	case "integer":
+		if schema.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		if schema.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "error":
+		// the parser documents the go errors with their own type
+		return "error"
+	case "array":
+		return "[]" + m.goType(schema.Items)
+	case "object":
+		if schema.Properties != nil && len(schema.Properties.Keys()) != 0 {
+			if b, err := json.Marshal(schema); err == nil {
+				if key, ok := m.components[string(b)]; ok {
+					return m.names[key]
+				}
+			}
+			var buffer bytes.Buffer
+			buffer.WriteString("struct {\n")
+			m.writeStructFields(&buffer, schema)
+			buffer.WriteString("}")
+			return buffer.String()
+		}
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			return "map[string]" + m.propertyType(schema.AdditionalProperties.Schema, true)
+		}
+		return "map[string]interface{}"
+	}
+	return "interface{}"
+}
+
+// isStruct tells if a schema is generated as a go struct, which then is passed around as a pointer
+func (m *typeMapper) isStruct(schema *oas.SchemaObject) bool {
+	resolved := m.openAPI.ResolveSchema(schema)
+	return resolved != nil && resolved.Ref == "" && resolved.Type == "object" &&
+		resolved.Properties != nil && len(resolved.Properties.Keys()) != 0
+}
+
+// propertyType is the type of a struct field. Optional and nullable structs become pointers.
+func (m *typeMapper) propertyType(property *oas.SchemaObject, required bool) string {
+	goType := m.goType(property)
+	if m.isStruct(property) && (!required || property.Nullable) {
+		return "*" + goType
+	}
+	return goType
+}
+
+func (m *typeMapper) writeStructFields(buffer *bytes.Buffer, schema *oas.SchemaObject) {
+	usedNames := map[string]int{}
+	for _, jsonName := range schema.Properties.Keys() {
+		property, ok := schema.PropertySchema(jsonName)
+		if !ok {
+			continue
+		}
+		fieldName := GoName(jsonName)
+		usedNames[fieldName]++
+		if usedNames[fieldName] > 1 {
+			fieldName += strconv.Itoa(usedNames[fieldName])
+		}
+		required := isRequired(schema, jsonName)
+		writeComment(buffer, fieldName, property.Description)
+		fmt.Fprintf(buffer, "%s %s `%s`\n", fieldName, m.propertyType(property, required), m.fieldTags(jsonName, property, required))
+	}
+}
+
+// writeModels writes a go type for every component schema
+func (m *typeMapper) writeModels(buffer *bytes.Buffer, skip map[string]bool) {
+	keys := make([]string, 0, len(m.names))
+	for key := range m.names {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return m.names[keys[i]] < m.names[keys[j]] })
+
+	for _, key := range keys {
+		if skip[key] {
+			continue
+		}
+		m.writeModel(buffer, m.names[key], m.openAPI.Components.Schemas[key])
+	}
+}
+
+func (m *typeMapper) writeModel(buffer *bytes.Buffer, typeName string, schema *oas.SchemaObject) {
+	writeComment(buffer, typeName, schema.Description)
+	enum, isEnum := schema.Enum.([]interface{})
+	switch {
+	case m.isStruct(schema) && schema.Ref == "":
+		fmt.Fprintf(buffer, "type %s struct {\n", typeName)
+		m.writeStructFields(buffer, schema)
+		buffer.WriteString("}\n\n")
+	case isEnum && len(enum) != 0 && schema.Type == "string":
+		fmt.Fprintf(buffer, "type %s string\n\nconst (\n", typeName)
+		for _, value := range enum {
+			fmt.Fprintf(buffer, "%s%s %s = %q\n", typeName, GoName(fmt.Sprint(value)), typeName, fmt.Sprint(value))
+		}
+		buffer.WriteString(")\n\n")
+	default:
+		fmt.Fprintf(buffer, "type %s %s\n\n", typeName, m.goType(schema))
+	}
+}
+
+// result describes what a successful call returns
+type result struct {
+	goType    string
+	isPointer bool
+	isText    bool
+}
+
+// successResult is the result of the first 2xx response with a body, nil when no success has a body
+func (m *typeMapper) successResult(operation *oas.OperationObject) *result {
+	var statuses []string
+	for status, response := range operation.Responses {
+		if strings.HasPrefix(status, "2") && len(response.Content) != 0 {
+			statuses = append(statuses, status)
+		}
+	}
+	if len(statuses) == 0 {
+		return nil
+	}
+	sort.Strings(statuses)
+	response := operation.Responses[statuses[0]]
+	mediaType, ok := response.Content[oas.ContentTypeJson]
+	if !ok {
+		return &result{goType: "string", isText: true}
+	}
+	return &result{goType: m.goType(&mediaType.Schema), isPointer: m.isStruct(&mediaType.Schema)}
+}
+
+func jsonTag(jsonName string, property *oas.SchemaObject, required bool) string {
+	if required {
+		return fmt.Sprintf(`json:"%s"`, jsonName)
+	}
+	return fmt.Sprintf(`json:"%s,omitempty"`, jsonName)
+}
+
+func isRequired(schema *oas.SchemaObject, name string) bool {
+	return isInList(schema.Required, name)
+}
+
+func isInList(list []string, s string) bool {
+	for i := range list {
+		if list[i] == s {
+			return true
+		}
+	}
+	return false
+}
+
+func writeComment(buffer *bytes.Buffer, name, description string) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return
+	}
+	for _, line := range strings.Split(name+" "+description, "\n") {
+		fmt.Fprintf(buffer, "// %s\n", strings.TrimSpace(line))
+	}
+}
@@ -0,0 +1,318 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/transform"
+)
+
+var tsIdentifierRegexp = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// tsMapper translates schemas into typescript type expressions
+type tsMapper struct {
+	openAPI *oas.OpenAPIObject
+	// names is the type declared for every component schema
+	names map[string]string
+	// components is the key of every component schema by its json, an inline schema identical to
+	// a component uses its type
+	components map[string]string
+	// declared is every type name declared in the file
+	declared map[string]bool
+}
+
+// tsOperationTypes are the type names of the params, request and response of an operation
+type tsOperationTypes struct {
+	params, request, response string
+}
+
+// GenerateTypeScript generates the typescript declarations of the component schemas, an interface
+// per object and a type alias per other schema, followed by the params, request and response types
+// of every operation and the Operations interface mapping the operation ids to them. The output
+// is valid both as a .ts and a .d.ts file.
+func GenerateTypeScript(openAPI *oas.OpenAPIObject) ([]byte, error) {
+	m := tsMapper{openAPI: openAPI, names: ComponentTypeNames(openAPI), components: transform.ComponentsByJSON(openAPI), declared: map[string]bool{}}
+	var buffer bytes.Buffer
+	buffer.WriteString("// Code generated by go-swagger3. DO NOT EDIT.\n\n")
+
+	keys := make([]string, 0, len(m.names))
+	for key := range m.names {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return m.names[keys[i]] < m.names[keys[j]] })
+	for _, key := range keys {
+		m.declared[m.names[key]] = true
+	}
+	for _, key := range keys {
+		m.writeComponent(&buffer, m.names[key], openAPI.Components.Schemas[key])
+	}
+
+	operations := Operations(openAPI)
+	types := make([]tsOperationTypes, len(operations))
+	for i, operation := range operations {
+		types[i] = m.writeOperation(&buffer, operation)
+	}
+	if len(operations) != 0 {
+		buffer.WriteString("export interface Operations {\n")
+		for i, operation := range operations {
+			key := operation.Operation.OperationID
+			if key == "" {
+				key = operation.Name
+			}
+			fmt.Fprintf(&buffer, "  %s: { params: %s; request: %s; response: %s };\n",
+				tsPropertyName(key), types[i].params, types[i].request, types[i].response)
+		}
+		buffer.WriteString("}\n")
+	}
+	return buffer.Bytes(), nil
+}
+
+func (m tsMapper) writeComponent(buffer *bytes.Buffer, typeName string, schema *oas.SchemaObject) {
+	if schema == nil {
+		return
+	}
+	writeJSDoc(buffer, "", schema)
+	if schema.Ref == "" && schema.Properties != nil && len(schema.Properties.Keys()) != 0 {
+		fmt.Fprintf(buffer, "export interface %s {\n", typeName)
+		m.writeProperties(buffer, schema, "  ")
+		buffer.WriteString("}\n\n")
+		return
+	}
+	fmt.Fprintf(buffer, "export type %s = %s;\n\n", typeName, m.tsType(schema, ""))
+}
+
+// writeProperties writes the members of an object type, the properties outside required are optional
+func (m tsMapper) writeProperties(buffer *bytes.Buffer, schema *oas.SchemaObject, indent string) {
+	for _, name := range schema.Properties.Keys() {
+		property, ok := schema.PropertySchema(name)
+		if !ok {
+			continue
+		}
+		writeJSDoc(buffer, indent, property)
+		readonly := ""
+		if property.ReadOnly {
+			readonly = "readonly "
+		}
+		optional := "?"
+		if isRequired(schema, name) {
+			optional = ""
+		}
+		fmt.Fprintf(buffer, "%s%s%s%s: %s;\n", indent, readonly, tsPropertyName(name), optional, m.tsType(property, indent))
+	}
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsAllowed() {
+		// the properties are not always of the type of the additional ones
+		fmt.Fprintf(buffer, "%s[key: string]: unknown;\n", indent)
+	}
+}
+
+// tsType returns the typescript type expression of a schema, indent is the one of the enclosing member
+func (m tsMapper) tsType(schema *oas.SchemaObject, indent string) string {
+	if schema == nil {
+		return "unknown"
+	}
+	if schema.Ref != "" {
+		if name, ok := m.names[strings.TrimPrefix(schema.Ref, oas.SchemaRefPrefix)]; ok {
+			return nullable(name, schema.Nullable)
+		}
+		return "unknown"
+	}
+	if enum := enumLiterals(schema.Enum); len(enum) != 0 {
+		return nullable(strings.Join(enum, " | "), schema.Nullable)
+	}
+	var tsType string
+	switch schema.Type {
+	case "string":
+		tsType = "string"
+	case "integer", "number":
+		tsType = "number"
+	case "boolean":
+		tsType = "boolean"
+	case "array":
+		items := m.tsType(schema.Items, indent)
+		if strings.ContainsAny(items, " |") {
+			tsType = "Array<" + items + ">"
+		} else {
+			tsType = items + "[]"
+		}
+	case "object", "":
+		if schema.Properties != nil && len(schema.Properties.Keys()) != 0 {
+			if b, err := json.Marshal(schema); err == nil {
+				if key, ok := m.components[string(b)]; ok {
+					return m.names[key]
+				}
+			}
+			var buffer bytes.Buffer
+			buffer.WriteString("{\n")
+			m.writeProperties(&buffer, schema, indent+"  ")
+			buffer.WriteString(indent + "}")
+			tsType = buffer.String()
+		} else if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			tsType = "Record<string, " + m.tsType(schema.AdditionalProperties.Schema, indent) + ">"
+		} else if schema.Type == "object" {
+			tsType = "Record<string, unknown>"
+		} else {
+			tsType = "unknown"
+		}
+	default:
+		tsType = "unknown"
+	}
+	return nullable(tsType, schema.Nullable)
+}
+
+// writeOperation writes the params, request and response types of an operation. A type named like
+// a component gets a "Type" suffix, unless it is that component.
+func (m tsMapper) writeOperation(buffer *bytes.Buffer, operation Operation) tsOperationTypes {
+	op := operation.Operation
+	fmt.Fprintf(buffer, "/** %s %s", operation.Method, operation.Path)
+	if op.Summary != "" {
+		fmt.Fprintf(buffer, " - %s", jsDocText(op.Summary))
+	}
+	buffer.WriteString(" */\n")
+
+	var members []string
+	for i := range op.Parameters {
+		parameter := m.openAPI.ResolveParameter(&op.Parameters[i])
+		optional := "?"
+		if parameter.Required || parameter.In == "path" {
+			optional = ""
+		}
+		members = append(members, fmt.Sprintf("  %s%s: %s;\n", tsPropertyName(parameter.Name), optional, m.tsType(parameter.Schema, "  ")))
+	}
+	types := tsOperationTypes{params: m.declare(operation.Name+"Params", "")}
+	if len(members) == 0 {
+		fmt.Fprintf(buffer, "export type %s = Record<string, never>;\n", types.params)
+	} else {
+		fmt.Fprintf(buffer, "export interface %s {\n%s}\n", types.params, strings.Join(members, ""))
+	}
+
+	request := "void"
+	if op.RequestBody != nil {
+		request = m.contentType(op.RequestBody.Content)
+		if !op.RequestBody.Required {
+			request += " | undefined"
+		}
+	}
+	types.request = m.writeAlias(buffer, operation.Name+"Request", request)
+
+	response := "void"
+	var statuses []string
+	for status := range op.Responses {
+		if strings.HasPrefix(status, "2") && len(op.Responses[status].Content) != 0 {
+			statuses = append(statuses, status)
+		}
+	}
+	if len(statuses) != 0 {
+		sort.Strings(statuses)
+		response = m.contentType(op.Responses[statuses[0]].Content)
+	}
+	types.response = m.writeAlias(buffer, operation.Name+"Response", response)
+	buffer.WriteString("\n")
+	return types
+}
+
+// writeAlias declares a type alias and returns its name, the type itself when it already has the name
+func (m tsMapper) writeAlias(buffer *bytes.Buffer, name, tsType string) string {
+	if name = m.declare(name, tsType); name != tsType {
+		fmt.Fprintf(buffer, "export type %s = %s;\n", name, tsType)
+	}
+	return name
+}
+
+// declare returns an undeclared name for a type, or the type when it is already declared with the name
+func (m tsMapper) declare(name, tsType string) string {
+	if name == tsType {
+		return name
+	}
+	declared := name
+	for m.declared[declared] {
+		declared += "Type"
+	}
+	m.declared[declared] = true
+	return declared
+}
+
+// contentType is the type of a json body, string for the other content types
+func (m tsMapper) contentType(content map[string]*oas.MediaTypeObject) string {
+	if mediaType, ok := content[oas.ContentTypeJson]; ok && mediaType != nil {
+		return m.tsType(&mediaType.Schema, "")
+	}
+	if mediaType, ok := content[oas.ContentTypeForm]; ok && mediaType != nil {
+		return "FormData"
+	}
+	if len(content) == 0 {
+		return "void"
+	}
+	return "string"
+}
+
+func writeJSDoc(buffer *bytes.Buffer, indent string, schema *oas.SchemaObject) {
+	var lines []string
+	if description := strings.TrimSpace(schema.Description); description != "" {
+		lines = append(lines, strings.Split(jsDocText(description), "\n")...)
+	}
+	if schema.Example != nil {
+		if example, err := json.Marshal(schema.Example); err == nil {
+			lines = append(lines, "@example "+jsDocText(string(example)))
+		}
+	}
+	if schema.Deprecated {
+		lines = append(lines, "@deprecated")
+	}
+	switch len(lines) {
+	case 0:
+	case 1:
+		fmt.Fprintf(buffer, "%s/** %s */\n", indent, lines[0])
+	default:
+		fmt.Fprintf(buffer, "%s/**\n", indent)
+		for _, line := range lines {
+			fmt.Fprintf(buffer, "%s * %s\n", indent, strings.TrimSpace(line))
+		}
+		fmt.Fprintf(buffer, "%s */\n", indent)
+	}
+}
+
+// jsDocText keeps a text from closing the comment it is written in
+func jsDocText(text string) string {
+	return strings.ReplaceAll(text, "*/", "*\\/")
+}
+
+// enumLiterals lists the values of an enum as typescript literals
+func enumLiterals(enum interface{}) []string {
+	b, err := json.Marshal(enum)
+	if err != nil || enum == nil {
+		return nil
+	}
+	var values []interface{}
+	if err := json.Unmarshal(b, &values); err != nil {
+		return nil
+	}
+	var literals []string
+	for _, value := range values {
+		literal, err := json.Marshal(value)
+		if err == nil {
+			literals = append(literals, string(literal))
+		}
+	}
+	return literals
+}
+
+func nullable(tsType string, isNullable bool) string {
+	if isNullable {
+		return tsType + " | null"
+	}
+	return tsType
+}
+
+// tsPropertyName quotes the names that are not identifiers, eg. "Client-Version"
+func tsPropertyName(name string) string {
+	if tsIdentifierRegexp.MatchString(name) {
+		return name
+	}
+	quoted, _ := json.Marshal(name)
+	return string(quoted)
+}
@@ -0,0 +1,40 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/iancoleman/orderedmap"
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GenerateTypeScript(t *testing.T) {
+	openAPI := getClientOpenAPIObject()
+	settingsProperties := orderedmap.New()
+	settingsProperties.Set("theme", &oas.SchemaObject{Type: "string", Enum: []interface{}{"light", "dark"}})
+	settingsProperties.Set("created_at", &oas.SchemaObject{Type: "string", Format: "date-time", ReadOnly: true, Description: "creation date", Example: "2021-01-01T00:00:00Z"})
+	settingsProperties.Set("legacy", &oas.SchemaObject{Type: "boolean", Deprecated: true})
+	openAPI.Components.Schemas["model.Settings"] = &oas.SchemaObject{Type: "object", Properties: settingsProperties, AdditionalProperties: oas.AllowAdditionalProperties(true)}
+	openAPI.Components.Schemas["model.GetUserResponse"] = &oas.SchemaObject{Type: "object", AdditionalProperties: oas.AllowAdditionalProperties(true)}
+
+	source, err := GenerateTypeScript(openAPI)
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(source), "export interface User {\n  id: number;\n  name?: string;\n}\n")
+	assert.Contains(t, string(source), `export interface Settings {
+  theme?: "light" | "dark";
+  /**
+   * creation date
+   * @example "2021-01-01T00:00:00Z"
+   */
+  readonly created_at?: string;
+  /** @deprecated */
+  legacy?: boolean;
+  [key: string]: unknown;
+}
+`)
+	assert.Contains(t, string(source), "export type GetUserResponse = Record<string, unknown>;\n")
+	assert.Contains(t, string(source), "/** GET /users/{id} */\nexport interface GetUserParams {\n  id: number;\n  fields?: string[];\n}\nexport type GetUserRequest = void;\nexport type GetUserResponseType = User;\n")
+	assert.Contains(t, string(source), "  GetUser: { params: GetUserParams; request: GetUserRequest; response: GetUserResponseType };\n")
+	assert.Contains(t, string(source), "  DeleteUsersByID: { params: DeleteUsersByIDParams; request: DeleteUsersByIDRequest; response: DeleteUsersByIDResponse };\n")
+}
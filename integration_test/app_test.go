@@ -1,14 +1,22 @@
 package integration_test
 
 import (
+	"encoding/json"
 	"fmt"
+	goparser "go/parser"
+	"go/token"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/nsf/jsondiff"
 
+	"github.com/parvez3019/go-swagger3/generator"
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
 	"github.com/parvez3019/go-swagger3/parser"
+	"github.com/parvez3019/go-swagger3/parser/schema"
+	"github.com/parvez3019/go-swagger3/reader"
 	"github.com/parvez3019/go-swagger3/writer"
 	"github.com/stretchr/testify/assert"
 )
@@ -38,6 +46,429 @@ func Test_GenerateExpectedSpecWithPkg(t *testing.T) {
 
 }
 
+func Test_BundleTreeGivesExpectedSpec(t *testing.T) {
+	openApiObject := parseFixture(t, "test_data", "", nil)
+	dir := t.TempDir()
+	assert.NoError(t, writer.NewFileWriter().WriteTree(openApiObject, dir))
+
+	bundled, err := reader.Bundle(filepath.Join(dir, writer.TreeRootFile))
+	assert.NoError(t, err)
+	actual, _ := json.Marshal(bundled)
+	diff, _ := jsondiff.Compare([]byte(LoadJSONAsString("test_data/spec/expected.json")), actual, &jsondiff.Options{})
+
+	assert.Equal(t, jsondiff.FullMatch, diff)
+}
+
+func Test_WriteGoEmbedsSpec(t *testing.T) {
+	openApiObject := parseFixture(t, "test_data", "", nil)
+	path := filepath.Join(t.TempDir(), "docs", "spec_gen.go")
+	assert.NoError(t, writer.NewFileWriter().WriteGo(openApiObject, path, "", true))
+
+	file, err := goparser.ParseFile(token.NewFileSet(), path, nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "docs", file.Name.Name)
+	var declarations []string
+	for _, name := range []string{"SpecJSON", "SpecYAML", "JSONHandler", "YAMLHandler", "Handler", "OpenAPI"} {
+		if file.Scope.Lookup(name) != nil {
+			declarations = append(declarations, name)
+		}
+	}
+	assert.Len(t, declarations, 6)
+
+	path = filepath.Join(t.TempDir(), "go-swagger3", "spec_gen.go")
+	assert.NoError(t, writer.NewFileWriter().WriteGo(openApiObject, path, "", false))
+	file, err = goparser.ParseFile(token.NewFileSet(), path, nil, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "goswagger3", file.Name.Name)
+	assert.EqualError(t, writer.NewFileWriter().WriteGo(openApiObject, path, "go-docs", false),
+		fmt.Sprintf(`Invalid package name "go-docs" for %s, set it with --go-package`, path))
+}
+
+func Test_WriteJSONSchemas(t *testing.T) {
+	openApiObject := parseFixture(t, "test_data", "", nil)
+	dir := t.TempDir()
+	assert.NoError(t, writer.NewFileWriter().WriteJSONSchemas(openApiObject, dir, writer.JSONSchemaDraft07, writer.JSONSchemaRefsDefs))
+
+	var schema struct {
+		Schema     string                     `json:"$schema"`
+		Properties map[string]json.RawMessage `json:"properties"`
+		Defs       map[string]json.RawMessage `json:"definitions"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(LoadJSONAsString(filepath.Join(dir, "CreateUserRequest.json"))), &schema))
+	assert.Equal(t, "http://json-schema.org/draft-07/schema#", schema.Schema)
+	assert.JSONEq(t, `{"type": "integer", "exclusiveMinimum": 18, "exclusiveMaximum": 256}`, string(schema.Properties["age"]))
+	assert.JSONEq(t, `{"type": ["array", "null"], "items": {"type": "string"}, "maxItems": 100, "minItems": 1, "uniqueItems": true, "writeOnly": true}`, string(schema.Properties["roles"]))
+
+	assert.NoError(t, json.Unmarshal([]byte(LoadJSONAsString(filepath.Join(dir, "GetPogsResponse.json"))), &schema))
+	assert.JSONEq(t, `{"type": "object", "$ref": "#/definitions/Bar"}`, string(schema.Properties["field4"]))
+	assert.Contains(t, schema.Defs, "Bar")
+}
+
+func Test_SchemaCollisionRenamesByPackage(t *testing.T) {
+	openApiObject := parseFixture(t, "collision_data", "", nil)
+	assert.Len(t, openApiObject.Components.Schemas, 4)
+	assert.Contains(t, openApiObject.Components.Schemas, "billing.Error")
+	assert.Contains(t, openApiObject.Components.Schemas, "auth.Error")
+	invoice := openApiObject.Components.Schemas["Invoice"]
+	invoiceError, _ := invoice.PropertySchema("error")
+	assert.Equal(t, "#/components/schemas/billing.Error", invoiceError.Ref)
+	responses := openApiObject.Paths["/invoices/{id}"].Get.Responses
+	assert.Equal(t, "#/components/schemas/auth.Error", responses["401"].Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/billing.Error", responses["402"].Content["application/json"].Schema.Ref)
+}
+
+func Test_SchemaNaming(t *testing.T) {
+	p, err := parser.NewParser("collision_data", "collision_data/server/main.go", "", false, false, false).
+		SchemaNaming("{{.Pkg | title}}{{.Type}}", map[string]string{"billing.Invoice": "Bill"}).
+		Init()
+	if err != nil {
+		t.Fatalf("could not init parser - Error %s", err.Error())
+	}
+	openApiObject, err := p.Parse()
+	if err != nil {
+		t.Fatalf("could not parse - Error %s", err.Error())
+	}
+	var names []string
+	for name := range openApiObject.Components.Schemas {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{"Bill", "BillingError", "AuthError", "Settlement"}, names)
+	responses := openApiObject.Paths["/invoices/{id}"].Get.Responses
+	assert.Equal(t, "#/components/schemas/Bill", responses["200"].Content["application/json"].Schema.Ref)
+	invoiceError, _ := openApiObject.Components.Schemas["Bill"].PropertySchema("error")
+	assert.Equal(t, "#/components/schemas/BillingError", invoiceError.Ref)
+	payment := openApiObject.Paths["/payments/{id}"].Get.Responses["200"].Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/Settlement", payment.Ref, "the @SchemaName of the type")
+}
+
+func Test_SchemaCollisionFails(t *testing.T) {
+	_, err := fixtureParser(t, "collision_data", "", func(p *parser.Parser) *parser.Parser {
+		return p.SchemaCollision(parser.SchemaCollisionFail)
+	}).Parse()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), filepath.Join("auth", "error.go")+":4:6")
+	assert.Contains(t, err.Error(), filepath.Join("billing", "error.go")+":4:6")
+}
+
+func Test_TypeMappings(t *testing.T) {
+	openApiObject := parseFixture(t, "mapping_data", "mapping_data/handler", func(p *parser.Parser) *parser.Parser {
+		return p.TypeMappingFile("mapping_data/mappings.yml")
+	})
+	order := openApiObject.Components.Schemas["Order"]
+	for name, expected := range map[string]string{
+		"id":        `{"type": "string", "format": "uuid", "example": "3fa85f64-5717-4562-b3fc-2c963f66afa6"}`,
+		"total":     `{"type": "string", "pattern": "^-?[0-9]+(\\.[0-9]+)?$", "example": "12.50"}`,
+		"paid":      `{"type": "string", "pattern": "^[0-9]+\\.[0-9]{2}$", "example": "12.50"}`,
+		"delay":     `{"type": "integer", "format": "int64", "example": 1000000000}`,
+		"receipt":   `{"type": "string", "format": "byte"}`,
+		"note":      `{"type": "string", "nullable": true}`,
+		"metadata":  `{}`,
+		"reference": `{"type": "string", "format": "uuid"}`,
+		"lines":     `{"type": "array", "items": {"type": "string"}}`,
+		"wallet":    `{"type": "string"}`,
+		"count":     `{"type": "integer", "format": "int64", "nullable": true}`,
+	} {
+		property, _ := order.PropertySchema(name)
+		actual, _ := json.Marshal(property)
+		assert.JSONEq(t, expected, string(actual), name)
+	}
+	param := openApiObject.Paths["/orders/{id}"].Get.Parameters[0]
+	assert.Equal(t, "uuid", param.Schema.Format)
+	// the go type of a field documented with swaggertype is not parsed
+	assert.NotContains(t, openApiObject.Components.Schemas, "Wallet")
+
+	_, err := fixtureParser(t, "mapping_data", "mapping_data/invalid", nil).Parse()
+
+	assert.EqualError(t, err, `field Amount of example.com.store.invalid.Refund: invalid swaggertype "primitive": primitive needs the type of its values, eg. primitive,integer`)
+}
+
+func Test_MarshalerSchemas(t *testing.T) {
+	openApiObject := parseFixture(t, "mapping_data", "mapping_data/handler", nil)
+	schemas := openApiObject.Components.Schemas
+	for name, expected := range map[string]string{
+		"Currency": `{"type": "string"}`,
+		"Rate":     `{}`,
+		"Fee":      `{"type": "integer", "format": "int64", "minimum": 1}`,
+	} {
+		actual, _ := json.Marshal(schemas[name])
+		assert.JSONEq(t, expected, string(actual), name)
+	}
+}
+
+func Test_InlineStructs(t *testing.T) {
+	openApiObject := parseFixture(t, "inline_data", "", nil)
+	schemas := openApiObject.Components.Schemas
+	items, _ := schemas["Order"].PropertySchema("items")
+	actual, _ := json.Marshal(items)
+	assert.JSONEq(t, `{"type": "array", "description": "Items of the order", "items": {"type": "object", "properties": {
+		"sku": {"type": "string", "example": "A-1"},
+		"quantity": {"type": "integer", "minimum": 1},
+		"options": {"type": "object", "properties": {"gift": {"type": "boolean"}}}}}}`, string(actual))
+	meta, _ := schemas["Order"].PropertySchema("meta")
+	actual, _ = json.Marshal(meta)
+	assert.JSONEq(t, `{"type": "object", "additionalProperties": {"type": "object", "properties": {"value": {"type": "string"}}}}`, string(actual))
+	actual, _ = json.Marshal(schemas["Lines"])
+	assert.JSONEq(t, `{"type": "array", "items": {"type": "object", "properties": {"label": {"type": "string"}}}}`, string(actual))
+
+	cancel := openApiObject.Paths["/orders/{id}/cancel"].Post
+	assert.Equal(t, "#/components/schemas/CancelOrderRequest", cancel.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/CancelOrderResponse", cancel.Responses["200"].Content["application/json"].Schema.Ref)
+	assert.Contains(t, schemas, "RefundOrderRefunds")
+}
+
+func Test_HoistInlineStructs(t *testing.T) {
+	openApiObject := parseFixture(t, "inline_data", "", func(p *parser.Parser) *parser.Parser {
+		return p.HoistInlineStructs(true)
+	})
+	schemas := openApiObject.Components.Schemas
+	items, _ := schemas["Order"].PropertySchema("items")
+	assert.Equal(t, "#/components/schemas/OrderItems", items.Items.Ref)
+	options, _ := schemas["OrderItems"].PropertySchema("options")
+	assert.Equal(t, "#/components/schemas/OrderItemsOptions", options.Ref)
+	meta, _ := schemas["Order"].PropertySchema("meta")
+	assert.Equal(t, "#/components/schemas/OrderMeta", meta.AdditionalProperties.Schema.Ref)
+	assert.Contains(t, schemas, "OrderItemsOptions")
+}
+
+func Test_MapSchemas(t *testing.T) {
+	openApiObject := parseFixture(t, "inline_data", "", nil)
+	catalog := openApiObject.Components.Schemas["Catalog"]
+	for name, expected := range map[string]string{
+		"products": `{"type": "object", "additionalProperties": {"$ref": "#/components/schemas/Product"}}`,
+		"byRank":   `{"type": "object", "additionalProperties": {"$ref": "#/components/schemas/Product"}, "x-key-type": "int"}`,
+		"tags":     `{"type": "object", "additionalProperties": {"type": "array", "items": {"type": "string"}}}`,
+		"groups": `{"type": "object", "additionalProperties": {"type": "object", "additionalProperties":
+			{"type": "array", "items": {"$ref": "#/components/schemas/Product"}}}}`,
+		"strict": `{"type": "object", "properties": {"name": {"type": "string"}}, "additionalProperties": false}`,
+	} {
+		property, _ := catalog.PropertySchema(name)
+		actual, _ := json.Marshal(property)
+		assert.JSONEq(t, expected, string(actual), name)
+	}
+	actual, _ := json.Marshal(openApiObject.Components.Schemas["Index"])
+	assert.JSONEq(t, `{"type": "object", "additionalProperties": {"$ref": "#/components/schemas/Product"}}`, string(actual))
+
+	products := openApiObject.Paths["/products"].Get.Responses["200"].Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/Product", products.AdditionalProperties.Schema.Ref)
+	ranks := openApiObject.Paths["/products/ranks"].Put.RequestBody.Content["application/json"].Schema
+	actual, _ = json.Marshal(ranks)
+	assert.JSONEq(t, `{"type": "object", "additionalProperties": {"type": "array", "items": {"$ref": "#/components/schemas/Product"}}, "x-key-type": "int"}`, string(actual))
+}
+
+func Test_RecursiveSchemas(t *testing.T) {
+	openApiObject := parseFixture(t, "recursion_data", "", nil)
+	_, err := json.Marshal(openApiObject)
+	assert.NoError(t, err)
+	schemas := openApiObject.Components.Schemas
+	actual, _ := json.Marshal(schemas["Node"])
+	assert.JSONEq(t, `{"type": "object", "properties": {
+		"name": {"type": "string"},
+		"parent": {"type": "object", "$ref": "#/components/schemas/Node"},
+		"children": {"type": "array", "items": {"$ref": "#/components/schemas/Node"}},
+		"index": {"type": "object", "additionalProperties": {"$ref": "#/components/schemas/Node"}}}}`, string(actual))
+
+	// Entity is parsed first and refers to Vertex, which embeds it
+	assert.ElementsMatch(t, []string{"edges", "id", "owner"}, schemas["Vertex"].Properties.Keys())
+	owner, _ := schemas["Vertex"].PropertySchema("owner")
+	assert.Equal(t, "#/components/schemas/Vertex", owner.Ref)
+	from, _ := schemas["Edge"].PropertySchema("from")
+	assert.Equal(t, "#/components/schemas/Vertex", from.Ref)
+
+	replies, _ := schemas["Thread"].PropertySchema("replies")
+	assert.Equal(t, "#/components/schemas/Reply", replies.Items.Ref)
+	thread, _ := schemas["Reply"].PropertySchema("thread")
+	assert.Equal(t, "#/components/schemas/Thread", thread.Ref)
+	reply := openApiObject.Paths["/threads/{id}/replies"].Post
+	assert.Equal(t, "#/components/schemas/Reply", reply.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/Thread", reply.Responses["200"].Content["application/json"].Schema.Ref)
+}
+
+func Test_JSONFieldNames(t *testing.T) {
+	openApiObject := parseFixture(t, "json_data", "", nil)
+	account := openApiObject.Components.Schemas["Account"]
+	// createdAt of Base and Audit hide each other, the tagged Version of Audit hides the one of Base
+	assert.Equal(t, []string{"id", "by", "Version", "owner", "Labels", "token", "name", "balance", "active",
+		"Nickname", "-", "First", "Last", "DisplayName"}, account.Properties.Keys())
+	for name, expected := range map[string]string{
+		"id":      `{"type": "string", "format": "int64"}`,
+		"balance": `{"type": "string", "format": "double"}`,
+		"active":  `{"type": "string"}`,
+		"owner":   `{"type": "object", "$ref": "#/components/schemas/Owner"}`,
+		"Labels":  `{"type": "object", "$ref": "#/components/schemas/Labels"}`,
+	} {
+		property, _ := account.PropertySchema(name)
+		actual, _ := json.Marshal(property)
+		assert.JSONEq(t, expected, string(actual), name)
+	}
+	assert.Equal(t, []string{"user_id", "followers", "post_count"}, openApiObject.Components.Schemas["Profile"].Properties.Keys())
+}
+
+func Test_JSONNaming(t *testing.T) {
+	openApiObject := parseFixture(t, "json_data", "", func(p *parser.Parser) *parser.Parser {
+		return p.JSONNaming(schema.JSONNamingProtojson)
+	})
+	profile := openApiObject.Components.Schemas["Profile"]
+	assert.Equal(t, []string{"userId", "followers", "postCount"}, profile.Properties.Keys())
+	followers, _ := profile.PropertySchema("followers")
+	assert.Equal(t, "string", followers.Type)
+
+	openApiObject = parseFixture(t, "json_data", "", func(p *parser.Parser) *parser.Parser {
+		return p.JSONNaming(schema.JSONNamingCamel)
+	})
+	assert.Equal(t, []string{"id", "version", "by", "Version", "owner", "labels", "token", "name", "balance", "active",
+		"nickname", "-", "first", "last", "displayName"}, openApiObject.Components.Schemas["Account"].Properties.Keys())
+
+	_, err := parser.NewParser("json_data", "json_data/server/main.go", "", false, false, true).JSONNaming("kebab").Init()
+	assert.Error(t, err)
+}
+
+func Test_RequiredPolicy(t *testing.T) {
+	openApiObject := parseFixture(t, "json_data", "", nil)
+	assert.Empty(t, openApiObject.Components.Schemas["User"].Required)
+
+	openApiObject = parseFixture(t, "json_data", "", func(p *parser.Parser) *parser.Parser {
+		return p.RequiredPolicy("validate,response:non-pointer")
+	})
+	schemas := openApiObject.Components.Schemas
+	// User is a response, CreateUserRequest a request body and Address both
+	assert.Equal(t, []string{"id", "name", "address"}, schemas["User"].Required)
+	assert.Equal(t, []string{"name"}, schemas["CreateUserRequest"].Required)
+	assert.Equal(t, []string{"street"}, schemas["Address"].Required)
+
+	_, err := parser.NewParser("json_data", "json_data/server/main.go", "", false, false, true).
+		RequiredPolicy("request:always").
+		Init()
+	assert.Error(t, err)
+}
+
+func Test_DirectionVariants(t *testing.T) {
+	openApiObject := parseFixture(t, "json_data", "", nil)
+	assert.NotContains(t, openApiObject.Components.Schemas, "MemberInput")
+
+	openApiObject = parseFixture(t, "json_data", "", func(p *parser.Parser) *parser.Parser {
+		return p.DirectionVariants(true)
+	})
+	schemas := openApiObject.Components.Schemas
+	// Member and Team are both sent and returned, Invite is only sent and Session only returned
+	assert.Equal(t, []string{"id", "name", "team"}, schemas["Member"].Properties.Keys())
+	assert.Equal(t, []string{"name", "password", "team"}, schemas["MemberInput"].Properties.Keys())
+	team, _ := schemas["MemberInput"].PropertySchema("team")
+	assert.Equal(t, "#/components/schemas/TeamInput", team.Ref)
+	assert.Equal(t, []string{"name"}, schemas["TeamInput"].Properties.Keys())
+	assert.Equal(t, []string{"email"}, schemas["Invite"].Properties.Keys())
+	assert.Equal(t, []string{"token"}, schemas["Session"].Properties.Keys())
+	assert.NotContains(t, schemas, "InviteInput")
+	assert.NotContains(t, schemas, "UserInput")
+
+	op := openApiObject.Paths["/members"].Post
+	assert.Equal(t, "#/components/schemas/MemberInput", op.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/Member", op.Responses["201"].Content["application/json"].Schema.Ref)
+}
+
+func Test_SchemaKeywords(t *testing.T) {
+	openApiObject := parseFixture(t, "keywords_data", "keywords_data/handler", nil)
+	product := openApiObject.Components.Schemas["Product"]
+	assert.Equal(t, &oas.DiscriminatorObject{PropertyName: "kind"}, product.Discriminator)
+	kind, _ := product.PropertySchema("kind")
+	assert.Equal(t, []interface{}{"product"}, kind.Enum)
+	id, _ := product.PropertySchema("id")
+	assert.Equal(t, &oas.XMLObject{Attribute: true}, id.XML)
+	assert.Equal(t, &oas.ExternalDocsObject{URL: "https://example.com/ids", Description: "How ids are made"}, id.ExternalDocs)
+	price, _ := product.PropertySchema("price")
+	assert.Equal(t, 9.99, price.Default)
+	assert.Equal(t, 0.01, price.MultipleOf)
+	quantity, _ := product.PropertySchema("quantity")
+	assert.Equal(t, int64(1), quantity.Default)
+	active, _ := product.PropertySchema("active")
+	assert.Equal(t, true, active.Default)
+	tags, _ := product.PropertySchema("tags")
+	assert.Equal(t, []interface{}{"new"}, tags.Default)
+	assert.Equal(t, &oas.XMLObject{Name: "tags", Wrapped: true}, tags.XML)
+	assert.Equal(t, &oas.XMLObject{Name: "tag"}, tags.Items.XML)
+	currency, _ := product.PropertySchema("currency")
+	assert.Equal(t, "EUR", currency.Default)
+	assert.Equal(t, &oas.XMLObject{Name: "currency_code"}, currency.XML)
+	status, _ := product.PropertySchema("status")
+	assert.Equal(t, &oas.SchemaObject{Enum: []interface{}{"deleted", "archived"}}, status.Not)
+
+	dir := t.TempDir()
+	assert.NoError(t, writer.NewFileWriter().WriteJSONSchemas(openApiObject, dir, writer.JSONSchemaDraft07, writer.JSONSchemaRefsDefs))
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(LoadJSONAsString(filepath.Join(dir, "Product.json"))), &schema))
+	assert.JSONEq(t, `{"type": "string", "const": "product"}`, string(schema.Properties["kind"]))
+
+	_, err := fixtureParser(t, "keywords_data", "keywords_data/invalid", nil).Parse()
+
+	assert.EqualError(t, err, `field Percent of example.com.catalog.invalid.Discount: invalid default "ten": strconv.ParseInt: parsing "ten": invalid syntax`)
+
+	_, err = fixtureParser(t, "keywords_data", "keywords_data/invalidexample", nil).Parse()
+
+	assert.EqualError(t, err, `field Uses of example.com.catalog.invalidexample.Coupon: invalid example "many": strconv.ParseInt: parsing "many": invalid syntax`)
+}
+
+func Test_ServerRoundTrip(t *testing.T) {
+	openApiObject := parseFixture(t, "test_data", "", nil)
+
+	files, err := generator.GenerateServer(&openApiObject, generator.ServerOptions{Package: "api", ModelsImportPath: "example.com/api/model"})
+	assert.NoError(t, err)
+	dir := t.TempDir()
+	files["go.mod"] = []byte("module example.com/api\n\ngo 1.14\n")
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		assert.NoError(t, ioutil.WriteFile(path, content, 0644))
+	}
+
+	// the generated module has no server/main.go, its main file is doc.go
+	p, err := parser.NewParser(dir, filepath.Join(dir, "doc.go"), "", false, false, true).Init()
+	if err != nil {
+		t.Fatalf("could not init parser - Error %s", err.Error())
+	}
+	parsed, err := p.Parse()
+	if err != nil {
+		t.Fatalf("could not parse - Error %s", err.Error())
+	}
+
+	expected, _ := json.Marshal(openApiObject)
+	actual, _ := json.Marshal(parsed)
+	diff, text := jsondiff.Compare(expected, actual, &jsondiff.Options{})
+	assert.Equal(t, jsondiff.FullMatch, diff, text)
+}
+
+func Test_ImportRoundTrip(t *testing.T) {
+	openApiObject, err := reader.NewFileReader().Read("test_data/spec/expected.json")
+	if err != nil {
+		t.Fatalf("could not read the spec - Error %s", err.Error())
+	}
+
+	files, err := generator.GenerateAnnotated(&openApiObject, generator.AnnotatedOptions{Package: "api", ModelsImportPath: "example.com/api/model"})
+	assert.NoError(t, err)
+	dir := t.TempDir()
+	files["go.mod"] = []byte("module example.com/api\n\ngo 1.14\n")
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		assert.NoError(t, ioutil.WriteFile(path, content, 0644))
+	}
+
+	// the generated module has no server/main.go, its main file is doc.go
+	p, err := parser.NewParser(dir, filepath.Join(dir, "doc.go"), "", false, false, true).Init()
+	if err != nil {
+		t.Fatalf("could not init parser - Error %s", err.Error())
+	}
+	parsed, err := p.Parse()
+	if err != nil {
+		t.Fatalf("could not parse - Error %s", err.Error())
+	}
+
+	actual, _ := json.Marshal(parsed)
+	diff, text := jsondiff.Compare([]byte(LoadJSONAsString("test_data/spec/expected.json")), actual, &jsondiff.Options{})
+	assert.Equal(t, jsondiff.FullMatch, diff, text)
+}
+
 func LoadJSONAsString(path string) string {
 	file, err := os.Open(path)
 	if err != nil {
@@ -47,6 +478,31 @@ func LoadJSONAsString(path string) string {
 	return string(content)
 }
 
+// parseFixture parses the module of the test data in dir, whose main file is server/main.go,
+// configure sets the options of the parser when it is not nil
+func parseFixture(t *testing.T, dir, handlerPath string, configure func(p *parser.Parser) *parser.Parser) oas.OpenAPIObject {
+	t.Helper()
+	openApiObject, err := fixtureParser(t, dir, handlerPath, configure).Parse()
+	if err != nil {
+		t.Fatalf("could not parse %s - Error %s", dir, err.Error())
+	}
+	return openApiObject
+}
+
+// fixtureParser is the initialized parser of parseFixture, for the tests expecting a parsing error
+func fixtureParser(t *testing.T, dir, handlerPath string, configure func(p *parser.Parser) *parser.Parser) *parser.Parser {
+	t.Helper()
+	p := parser.NewParser(dir, filepath.Join(dir, "server", "main.go"), handlerPath, false, false, true)
+	if configure != nil {
+		p = configure(p)
+	}
+	initialized, err := p.Init()
+	if err != nil {
+		t.Fatalf("could not init parser %s - Error %s", dir, err.Error())
+	}
+	return initialized
+}
+
 func createSpecFile(generateYaml bool, schemaWithoutPkg bool) error {
 	p, err := parser.NewParser(
 		"test_data",
@@ -65,6 +521,9 @@ func createSpecFile(generateYaml bool, schemaWithoutPkg bool) error {
 		return err
 	}
 
+	if !schemaWithoutPkg {
+		writer.FilterSchemaWithoutPkg(openApiObject)
+	}
 	fw := writer.NewFileWriter()
-	return fw.Write(openApiObject, "test_data/spec/actual.json", generateYaml, schemaWithoutPkg)
+	return fw.Write(openApiObject, "test_data/spec/actual.json", generateYaml)
 }
@@ -0,0 +1,6 @@
+package auth
+
+// Error is an authentication failure
+type Error struct {
+	Reason string `json:"reason"`
+}
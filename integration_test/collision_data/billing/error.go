@@ -0,0 +1,18 @@
+package billing
+
+// Error is a billing failure
+type Error struct {
+	Invoice string `json:"invoice"`
+}
+
+// Invoice is a bill
+type Invoice struct {
+	ID    string `json:"id"`
+	Error Error  `json:"error"`
+}
+
+// Payment settles an invoice
+// @SchemaName Settlement
+type Payment struct {
+	Amount int `json:"amount"`
+}
@@ -0,0 +1,20 @@
+package handler
+
+import (
+	_ "example.com/shop/auth"
+	_ "example.com/shop/billing"
+)
+
+// @Title Get an invoice
+// @Success 200 {object} billing.Invoice
+// @Failure 402 {object} billing.Error
+// @Failure 401 {object} auth.Error
+// @Router /invoices/{id} [get]
+func GetInvoice() {
+}
+
+// @Title Get a payment
+// @Success 200 {object} billing.Payment
+// @Router /payments/{id} [get]
+func GetPayment() {
+}
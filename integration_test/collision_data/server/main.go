@@ -0,0 +1,7 @@
+package server
+
+// @Title Shop API
+// @Version 1.0
+func main() {
+
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	_ "example.com/orders/order"
+)
+
+// @Title Get an order
+// @Success 200 {object} order.Order
+// @Router /orders/{id} [get]
+func GetOrder() {
+}
+
+// @Title Get the lines of an order
+// @Success 200 {object} order.Lines
+// @Router /orders/{id}/lines [get]
+func GetLines() {
+}
+
+// @Title Cancel an order
+// @Param request body request true "Reason of the cancellation."
+// @Success 200 {object} Response
+// @Router /orders/{id}/cancel [post]
+func CancelOrder() {
+	type Response struct {
+		Cancelled bool `json:"cancelled"`
+	}
+	var request struct {
+		Reason string `json:"reason"`
+	}
+	_, _ = request, Response{}
+}
+
+// @Title Refund an order
+// @Success 200 {object} refunds
+// @Router /orders/{id}/refund [post]
+func RefundOrder() {
+	refunds := []struct {
+		Amount int `json:"amount"`
+	}{}
+	_ = refunds
+}
+
+// @Title Get the catalog
+// @Success 200 {object} order.Catalog
+// @Router /catalog [get]
+func GetCatalog() {
+}
+
+// @Title Get the products by name
+// @Success 200 {object} map[string]order.Product
+// @Router /products [get]
+func GetProducts() {
+}
+
+// @Title Rank the products
+// @Param ranks body map[int][]order.Product true "Products by rank."
+// @Success 200 {object} order.Index
+// @Router /products/ranks [put]
+func RankProducts() {
+}
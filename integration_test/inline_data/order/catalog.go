@@ -0,0 +1,21 @@
+package order
+
+// Product is an item of the catalog
+type Product struct {
+	Name string `json:"name"`
+}
+
+// Catalog indexes the products
+type Catalog struct {
+	Products map[string]Product              `json:"products"`
+	ByRank   map[int]*Product                `json:"byRank"`
+	Tags     map[string][]string             `json:"tags"`
+	Groups   map[string]map[string][]Product `json:"groups"`
+	Extra    map[string]interface{}          `json:"extra" additionalProperties:"true"`
+	Strict   struct {
+		Name string `json:"name"`
+	} `json:"strict" additionalProperties:"false"`
+}
+
+// Index is the products by name
+type Index map[string]Product
@@ -0,0 +1,21 @@
+package order
+
+// Order is a purchase
+type Order struct {
+	ID    string `json:"id"`
+	Items []struct {
+		SKU      string `json:"sku" example:"A-1"`
+		Quantity int    `json:"quantity" minimum:"1"`
+		Options  *struct {
+			Gift bool `json:"gift"`
+		} `json:"options,omitempty"`
+	} `json:"items" description:"Items of the order"`
+	Meta map[string]struct {
+		Value string `json:"value"`
+	} `json:"meta"`
+}
+
+// Lines are the lines of an invoice
+type Lines []struct {
+	Label string `json:"label"`
+}
@@ -0,0 +1,17 @@
+package handler
+
+import (
+	_ "example.com/accounts/model"
+)
+
+// @Title Get an account
+// @Success 200 {object} model.Account
+// @Router /accounts/{id} [get]
+func GetAccount() {
+}
+
+// @Title Get a profile
+// @Success 200 {object} model.Profile
+// @Router /profiles/{id} [get]
+func GetProfile() {
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	_ "example.com/accounts/model"
+)
+
+// @Title Create a member
+// @Param member body model.Member true "The member."
+// @Success 201 {object} model.Member
+// @Router /members [post]
+func CreateMember() {
+}
+
+// @Title Invite a member
+// @Param invite body model.Invite true "The invite."
+// @Success 200 {object} model.Session
+// @Router /members/invites [post]
+func InviteMember() {
+}
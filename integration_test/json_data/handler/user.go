@@ -0,0 +1,19 @@
+package handler
+
+import (
+	_ "example.com/accounts/model"
+)
+
+// @Title Create a user
+// @Param user body model.CreateUserRequest true "The user."
+// @Success 201 {object} model.User
+// @Router /users [post]
+func CreateUser() {
+}
+
+// @Title Update the address of a user
+// @Param address body model.Address true "The address."
+// @Success 200 {object} model.Address
+// @Router /users/{id}/address [put]
+func UpdateAddress() {
+}
@@ -0,0 +1,45 @@
+package model
+
+// Base is embedded in the accounts
+type Base struct {
+	ID        int64  `json:"id,string"`
+	CreatedAt string `json:"createdAt"`
+	Version   int
+}
+
+// Audit is embedded in the accounts next to Base
+type Audit struct {
+	CreatedAt string `json:"createdAt"`
+	By        string `json:"by"`
+	Version   int    `json:"Version"`
+}
+
+// Owner owns an account
+type Owner struct {
+	Name string `json:"name"`
+}
+
+// Labels of an account
+type Labels map[string]string
+
+type secrets struct {
+	Token string `json:"token"`
+}
+
+// Account of a user
+type Account struct {
+	Base
+	*Audit
+	Owner `json:"owner"`
+	Labels
+	secrets
+	password    string
+	Name        string  `json:"name"`
+	Balance     float64 `json:"balance,string"`
+	Active      bool    `json:"active,string"`
+	Nickname    string  `json:",omitempty"`
+	Dash        string  `json:"-,"`
+	Hidden      string  `json:"-"`
+	First, Last string
+	DisplayName string
+}
@@ -0,0 +1,27 @@
+package model
+
+// Team a member belongs to
+type Team struct {
+	ID   int64  `json:"id" readOnly:"true"`
+	Name string `json:"name"`
+}
+
+// Member is created and returned by the members api
+type Member struct {
+	ID       int64  `json:"id" readOnly:"true"`
+	Name     string `json:"name"`
+	Password string `json:"password" writeOnly:"true"`
+	Team     Team   `json:"team"`
+}
+
+// Invite is only sent to the members api
+type Invite struct {
+	Token string `json:"token" readOnly:"true"`
+	Email string `json:"email"`
+}
+
+// Session is only returned by the members api
+type Session struct {
+	Token  string `json:"token"`
+	Secret string `json:"secret" writeOnly:"true"`
+}
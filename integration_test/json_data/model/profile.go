@@ -0,0 +1,10 @@
+package model
+
+// Profile is a protobuf message
+type Profile struct {
+	state         int
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Followers     int64  `protobuf:"varint,2,opt,name=followers,proto3" json:"followers,omitempty"`
+	PostCount     int32  `protobuf:"varint,3,opt,name=post_count,proto3" json:"post_count,omitempty"`
+	unknownFields []byte
+}
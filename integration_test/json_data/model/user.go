@@ -0,0 +1,25 @@
+package model
+
+// Address of a user
+type Address struct {
+	Street string  `json:"street" validate:"required"`
+	City   string  `json:"city"`
+	Zip    *string `json:"zip"`
+	Note   string  `json:"note,omitempty"`
+}
+
+// User is returned by the users api
+type User struct {
+	ID      int64   `json:"id"`
+	Name    string  `json:"name" binding:"required,min=1"`
+	Email   *string `json:"email"`
+	Bio     string  `json:"bio,omitempty"`
+	Address Address `json:"address"`
+}
+
+// CreateUserRequest is the body creating a user
+type CreateUserRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email,omitempty"`
+	Age   int    `json:"age"`
+}
@@ -0,0 +1,11 @@
+package handler
+
+import (
+	_ "example.com/catalog/model"
+)
+
+// @Title Get a product
+// @Success 200 {object} model.Product
+// @Router /products/{id} [get]
+func GetProduct() {
+}
@@ -0,0 +1,12 @@
+package invalid
+
+// Discount with an invalid default
+type Discount struct {
+	Percent int `json:"percent" default:"ten"`
+}
+
+// @Title Get a discount
+// @Success 200 {object} Discount
+// @Router /discounts/{id} [get]
+func GetDiscount() {
+}
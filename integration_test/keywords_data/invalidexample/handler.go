@@ -0,0 +1,12 @@
+package invalidexample
+
+// Coupon with an invalid example
+type Coupon struct {
+	Uses int `json:"uses" example:"many"`
+}
+
+// @Title Get a coupon
+// @Success 200 {object} Coupon
+// @Router /coupons/{id} [get]
+func GetCoupon() {
+}
@@ -0,0 +1,13 @@
+package model
+
+// Product of the catalog
+type Product struct {
+	Kind     string   `json:"kind" discriminator:"true" const:"product"`
+	ID       string   `json:"id" xml:"id,attr" externalDocs:"https://example.com/ids,How ids are made"`
+	Price    float64  `json:"price" default:"9.99" multipleOf:"0.01"`
+	Quantity int      `json:"quantity" default:"1" minimum:"0"`
+	Active   bool     `json:"active" default:"true"`
+	Tags     []string `json:"tags" default:"[\"new\"]" xml:"tags>tag"`
+	Currency string   `json:"currency" default:"EUR" xml:"currency_code"`
+	Status   string   `json:"status" not:"deleted,archived"`
+}
@@ -0,0 +1,13 @@
+package handler
+
+import (
+	_ "example.com/store/store"
+	_ "github.com/google/uuid"
+)
+
+// @Title Get an order
+// @Param id path uuid.UUID true "Id of the order."
+// @Success 200 {object} store.Order
+// @Router /orders/{id} [get]
+func GetOrder() {
+}
@@ -0,0 +1,12 @@
+package invalid
+
+// Refund with a swaggertype missing the type of its values
+type Refund struct {
+	Amount int64 `json:"amount" swaggertype:"primitive"`
+}
+
+// @Title Refund an order
+// @Success 200 {object} Refund
+// @Router /refunds/{id} [post]
+func RefundOrder() {
+}
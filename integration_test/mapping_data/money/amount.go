@@ -0,0 +1,39 @@
+package money
+
+// Amount is marshalled as a decimal string
+type Amount struct {
+	units int64
+	cents int64
+}
+
+// Currency is marshalled as its ISO code
+type Currency struct {
+	code int
+}
+
+// MarshalText returns the ISO code of the currency
+func (c *Currency) MarshalText() ([]byte, error) {
+	return nil, nil
+}
+
+// Rate is marshalled as a fraction
+type Rate struct {
+	numerator   int64
+	denominator int64
+}
+
+// MarshalJSON returns the fraction of the rate
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return nil, nil
+}
+
+// Fee is marshalled as a number of cents
+// @Schema {"type": "integer", "format": "int64", "minimum": 1}
+type Fee struct {
+	cents int64
+}
+
+// MarshalJSON returns the number of cents of the fee
+func (f Fee) MarshalJSON() ([]byte, error) {
+	return nil, nil
+}
@@ -0,0 +1,7 @@
+package money
+
+// Wallet holds the balances of a customer, it is documented as its id
+type Wallet struct {
+	ID       string
+	Balances map[string]Amount
+}
@@ -0,0 +1,30 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"example.com/store/money"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gopkg.in/guregu/null.v4"
+)
+
+// Order is a purchase
+type Order struct {
+	ID        uuid.UUID       `json:"id"`
+	Total     decimal.Decimal `json:"total"`
+	Paid      money.Amount    `json:"paid"`
+	Delay     time.Duration   `json:"delay"`
+	Receipt   []byte          `json:"receipt"`
+	Note      null.String     `json:"note"`
+	Metadata  json.RawMessage `json:"metadata"`
+	Reference string          `json:"reference" swaggertype:"string,uuid"`
+	Lines     []int64         `json:"lines" swaggertype:"array,string"`
+	Currency  money.Currency  `json:"currency"`
+	Rate      money.Rate      `json:"rate"`
+	Fee       money.Fee       `json:"fee"`
+	Wallet    money.Wallet    `json:"wallet" swaggertype:"string"`
+	Count     sql.NullInt64   `json:"count"`
+}
@@ -0,0 +1,34 @@
+package graph
+
+import "example.com/graphs/tree"
+
+// Entity is the base of the graph objects
+type Entity struct {
+	ID    string  `json:"id"`
+	Owner *Vertex `json:"owner,omitempty"`
+}
+
+// Vertex is a vertex of a graph
+type Vertex struct {
+	Entity
+	Edges []*Edge `json:"edges"`
+}
+
+// Edge links two vertices
+type Edge struct {
+	From *Vertex   `json:"from"`
+	To   *Vertex   `json:"to"`
+	Tree tree.Node `json:"tree"`
+}
+
+// Thread is a discussion
+type Thread struct {
+	Title   string  `json:"title"`
+	Replies []Reply `json:"replies"`
+}
+
+// Reply answers a thread
+type Reply struct {
+	Text   string  `json:"text"`
+	Thread *Thread `json:"thread"`
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	_ "example.com/graphs/graph"
+	_ "example.com/graphs/tree"
+)
+
+// @Title Get an entity
+// @Success 200 {object} graph.Entity
+// @Router /entities/{id} [get]
+func GetEntity() {
+}
+
+// @Title Get a tree
+// @Success 200 {object} tree.Node
+// @Router /trees/{id} [get]
+func GetTree() {
+}
+
+// @Title Get a graph
+// @Success 200 {object} graph.Vertex
+// @Router /graphs/{id} [get]
+func GetGraph() {
+}
+
+// @Title Reply to a thread
+// @Param reply body graph.Reply true "The reply."
+// @Success 200 {object} graph.Thread
+// @Router /threads/{id}/replies [post]
+func PostReply() {
+}
@@ -0,0 +1,9 @@
+package tree
+
+// Node is a node of a tree
+type Node struct {
+	Name     string           `json:"name"`
+	Parent   *Node            `json:"parent,omitempty"`
+	Children []Node           `json:"children"`
+	Index    map[string]*Node `json:"index"`
+}
@@ -0,0 +1,343 @@
+package merger
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/parser/utils"
+)
+
+const (
+	CollisionPath           = "path"
+	CollisionOperationID    = "operationId"
+	CollisionSecurityScheme = "securityScheme"
+)
+
+var componentRefRegexp = regexp.MustCompile(`"\$ref":"#/components/(schemas|parameters)/([^"]+)"`)
+
+// Spec is the document of one service
+type Spec struct {
+	// Service names the service in the report and in the names of the renamed components
+	Service string
+	// Prefix is prepended to every path of the service, eg. "/users"
+	Prefix  string
+	OpenAPI oas.OpenAPIObject
+}
+
+// Collision is an operation of a path, an operationId or a security scheme defined by several services.
+// The operation and the security scheme of the first service are kept, the operations sharing an
+// operationId are all kept.
+type Collision struct {
+	Kind     string
+	Name     string
+	Services []string
+}
+
+// Rename is a component of a service renamed because another service defines a different component of the same name
+type Rename struct {
+	Service string
+	// Kind is "schemas" or "parameters"
+	Kind string
+	From string
+	To   string
+}
+
+type Report struct {
+	Collisions []Collision
+	Renames    []Rename
+}
+
+func (c Collision) String() string {
+	return fmt.Sprintf("%s %s is defined by %s", c.Kind, c.Name, strings.Join(c.Services, ", "))
+}
+
+func (r Rename) String() string {
+	return fmt.Sprintf("%s %s of %s renamed to %s", strings.TrimSuffix(r.Kind, "s"), r.From, r.Service, r.To)
+}
+
+// Merge combines the documents of several services into one: the paths with their prefix, the
+// components, the tags, the security schemes and requirements and the servers. Identical components
+// are kept once, a component differing from the one of an earlier service with the same name is renamed
+// "<service>_<name>" and the references of its service follow. The info is the one of the first service.
+func Merge(specs []Spec) (oas.OpenAPIObject, Report, error) {
+	renames := make([]map[string]string, len(specs))
+	for i := range renames {
+		renames[i] = map[string]string{}
+	}
+	// renaming a component changes the components referencing it, which may then differ in turn
+	for {
+		merged, report, changed, err := merge(specs, renames)
+		if err != nil || !changed {
+			return merged, report, err
+		}
+	}
+}
+
+// merge merges the specs with the given renames, it stops at the first new rename and reports it as a change
+func merge(specs []Spec, renames []map[string]string) (oas.OpenAPIObject, Report, bool, error) {
+	merged := oas.OpenAPIObject{
+		Version: oas.OpenAPIVersion,
+		Paths:   oas.PathsObject{},
+		Components: oas.ComponentsObject{
+			Schemas:         map[string]*oas.SchemaObject{},
+			SecuritySchemes: map[string]*oas.SecuritySchemeObject{},
+			Parameters:      map[string]*oas.ParameterObject{},
+		},
+	}
+	report := Report{}
+	m := reporter{first: map[string]string{}, collisions: map[string]*Collision{}}
+	// definitions is the json of every merged schema and parameter
+	definitions := map[string]map[string]string{"schemas": {}, "parameters": {}}
+	operationIDs := map[string]string{}
+
+	for i, spec := range specs {
+		openAPI, err := renameRefs(spec.OpenAPI, renames[i])
+		if err != nil {
+			return merged, report, false, fmt.Errorf("Can not merge the spec of %s: %v", spec.Service, err)
+		}
+		if i == 0 {
+			merged.Info = openAPI.Info
+			if openAPI.Version != "" {
+				merged.Version = openAPI.Version
+			}
+		}
+
+		for _, kind := range []string{"schemas", "parameters"} {
+			components := map[string]interface{}{}
+			if kind == "schemas" {
+				for name, schema := range openAPI.Components.Schemas {
+					components[name] = schema
+				}
+			} else {
+				for name, parameter := range openAPI.Components.Parameters {
+					components[name] = parameter
+				}
+			}
+			for _, name := range utils.SortedKeys(components) {
+				b, err := json.Marshal(components[name])
+				if err != nil {
+					return merged, report, false, err
+				}
+				if existing, ok := definitions[kind][name]; ok && existing != string(b) {
+					renames[i][kind+"/"+name] = unusedName(spec.Service+"_"+name, definitions[kind], components)
+					return merged, report, true, nil
+				}
+				definitions[kind][name] = string(b)
+				if kind == "schemas" {
+					merged.Components.Schemas[name] = openAPI.Components.Schemas[name]
+				} else {
+					merged.Components.Parameters[name] = openAPI.Components.Parameters[name]
+				}
+			}
+		}
+
+		for _, name := range utils.SortedKeys(openAPI.Components.SecuritySchemes) {
+			scheme := openAPI.Components.SecuritySchemes[name]
+			existing, ok := merged.Components.SecuritySchemes[name]
+			if !ok {
+				merged.Components.SecuritySchemes[name] = scheme
+				m.source(CollisionSecurityScheme, name, spec.Service)
+				continue
+			}
+			if !sameJSON(existing, scheme) {
+				m.collide(CollisionSecurityScheme, name, spec.Service)
+			}
+		}
+
+		for _, path := range utils.SortedKeys(openAPI.Paths) {
+			item := openAPI.Paths[path]
+			prefixed := prefixPath(spec.Prefix, path)
+			mergedItem, ok := merged.Paths[prefixed]
+			if !ok {
+				mergedItem = &oas.PathItemObject{Ref: item.Ref, Summary: item.Summary, Description: item.Description}
+				merged.Paths[prefixed] = mergedItem
+			}
+			for _, method := range oas.Methods {
+				operation := item.Operation(method)
+				if operation == nil {
+					continue
+				}
+				name := strings.ToLower(method) + " " + prefixed
+				if mergedItem.Operation(method) != nil {
+					m.collide(CollisionPath, name, spec.Service)
+					continue
+				}
+				m.source(CollisionPath, name, spec.Service)
+				mergedItem.SetOperation(method, operation)
+				if operation.OperationID == "" {
+					continue
+				}
+				if _, ok := operationIDs[operation.OperationID]; ok {
+					m.collide(CollisionOperationID, operation.OperationID, spec.Service)
+					continue
+				}
+				operationIDs[operation.OperationID] = spec.Service
+				m.source(CollisionOperationID, operation.OperationID, spec.Service)
+			}
+		}
+
+		merged.Tags = unionTags(merged.Tags, openAPI.Tags)
+		merged.Security = unionSecurity(merged.Security, openAPI.Security)
+		merged.Servers = unionServers(merged.Servers, openAPI.Servers)
+	}
+
+	report.Collisions = m.sorted()
+	for i, spec := range specs {
+		for _, key := range utils.SortedKeys(renames[i]) {
+			kindAndName := strings.SplitN(key, "/", 2)
+			report.Renames = append(report.Renames, Rename{Service: spec.Service, Kind: kindAndName[0], From: kindAndName[1], To: renames[i][key]})
+		}
+	}
+	return merged, report, false, nil
+}
+
+// reporter collects the collisions along with the service of the first definition
+type reporter struct {
+	first      map[string]string
+	collisions map[string]*Collision
+}
+
+func (r *reporter) source(kind, name, service string) {
+	if _, ok := r.first[kind+" "+name]; !ok {
+		r.first[kind+" "+name] = service
+	}
+}
+
+func (r *reporter) collide(kind, name, service string) {
+	key := kind + " " + name
+	collision, ok := r.collisions[key]
+	if !ok {
+		collision = &Collision{Kind: kind, Name: name, Services: []string{r.first[key]}}
+		r.collisions[key] = collision
+	}
+	collision.Services = append(collision.Services, service)
+}
+
+func (r *reporter) sorted() []Collision {
+	var collisions []Collision
+	for _, key := range utils.SortedKeys(r.collisions) {
+		collisions = append(collisions, *r.collisions[key])
+	}
+	return collisions
+}
+
+// renameRefs returns a copy of the spec where the renamed components and the references to them carry their new name
+func renameRefs(openAPI oas.OpenAPIObject, renames map[string]string) (oas.OpenAPIObject, error) {
+	b, err := json.Marshal(openAPI)
+	if err != nil {
+		return oas.OpenAPIObject{}, err
+	}
+	b = componentRefRegexp.ReplaceAllFunc(b, func(ref []byte) []byte {
+		match := componentRefRegexp.FindSubmatch(ref)
+		if renamed, ok := renames[string(match[1])+"/"+string(match[2])]; ok {
+			return []byte(`"$ref":"#/components/` + string(match[1]) + "/" + renamed + `"`)
+		}
+		return ref
+	})
+	var renamed oas.OpenAPIObject
+	if err := json.Unmarshal(b, &renamed); err != nil {
+		return oas.OpenAPIObject{}, err
+	}
+	for key, to := range renames {
+		kindAndName := strings.SplitN(key, "/", 2)
+		switch kindAndName[0] {
+		case "schemas":
+			if schema, ok := renamed.Components.Schemas[kindAndName[1]]; ok {
+				delete(renamed.Components.Schemas, kindAndName[1])
+				renamed.Components.Schemas[to] = schema
+			}
+		case "parameters":
+			if parameter, ok := renamed.Components.Parameters[kindAndName[1]]; ok {
+				delete(renamed.Components.Parameters, kindAndName[1])
+				renamed.Components.Parameters[to] = parameter
+			}
+		}
+	}
+	return renamed, nil
+}
+
+func prefixPath(prefix, path string) string {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix == "" {
+		return path
+	}
+	if !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+	if path == "/" {
+		return prefix
+	}
+	return prefix + path
+}
+
+func unusedName(name string, merged map[string]string, components map[string]interface{}) string {
+	unused := name
+	for i := 2; ; i++ {
+		_, isMerged := merged[unused]
+		_, isComponent := components[unused]
+		if !isMerged && !isComponent {
+			return unused
+		}
+		unused = name + strconv.Itoa(i)
+	}
+}
+
+func unionTags(tags, others []oas.TagObject) []oas.TagObject {
+	// the tags are copied so that the descriptions are never written into the input specs
+	tags = append([]oas.TagObject(nil), tags...)
+	for _, other := range others {
+		found := false
+		for i := range tags {
+			if tags[i].Name == other.Name {
+				found = true
+				if tags[i].Description == "" {
+					tags[i].Description = other.Description
+				}
+			}
+		}
+		if !found {
+			tags = append(tags, other)
+		}
+	}
+	return tags
+}
+
+func unionSecurity(security, others []map[string][]string) []map[string][]string {
+	for _, other := range others {
+		found := false
+		for _, requirement := range security {
+			if sameJSON(requirement, other) {
+				found = true
+			}
+		}
+		if !found {
+			security = append(security, other)
+		}
+	}
+	return security
+}
+
+func unionServers(servers, others []oas.ServerObject) []oas.ServerObject {
+	for _, other := range others {
+		found := false
+		for _, server := range servers {
+			if server.URL == other.URL {
+				found = true
+			}
+		}
+		if !found {
+			servers = append(servers, other)
+		}
+	}
+	return servers
+}
+
+func sameJSON(a, b interface{}) bool {
+	aJSON, aErr := json.Marshal(a)
+	bJSON, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && string(aJSON) == string(bJSON)
+}
@@ -0,0 +1,79 @@
+package merger
+
+import (
+	"testing"
+
+	"github.com/iancoleman/orderedmap"
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func serviceSpec(userProperty string) oas.OpenAPIObject {
+	userProperties := orderedmap.New()
+	userProperties.Set(userProperty, &oas.SchemaObject{Type: "string"})
+	listProperties := orderedmap.New()
+	listProperties.Set("users", &oas.SchemaObject{Type: "array", Items: &oas.SchemaObject{Ref: oas.SchemaRefPrefix + "User"}})
+	errorProperties := orderedmap.New()
+	errorProperties.Set("message", &oas.SchemaObject{Type: "string"})
+	return oas.OpenAPIObject{
+		Version: oas.OpenAPIVersion,
+		Info:    oas.InfoObject{Title: "Service " + userProperty},
+		Paths: oas.PathsObject{
+			"/users": {Get: &oas.OperationObject{
+				OperationID: "ListUsers",
+				Responses: oas.ResponsesObject{"200": {Content: map[string]*oas.MediaTypeObject{
+					oas.ContentTypeJson: {Schema: oas.SchemaObject{Ref: oas.SchemaRefPrefix + "UserList"}},
+				}}},
+			}},
+		},
+		Components: oas.ComponentsObject{Schemas: map[string]*oas.SchemaObject{
+			"User":     {Type: "object", Properties: userProperties},
+			"UserList": {Type: "object", Properties: listProperties},
+			"Error":    {Type: "object", Properties: errorProperties},
+		}},
+		Tags: []oas.TagObject{{Name: "users"}},
+	}
+}
+
+func Test_Merge(t *testing.T) {
+	merged, report, err := Merge([]Spec{
+		{Service: "a", Prefix: "/a", OpenAPI: serviceSpec("name")},
+		{Service: "b", Prefix: "/b/", OpenAPI: serviceSpec("email")},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Service name", merged.Info.Title)
+	assert.Len(t, merged.Paths, 2)
+	assert.Equal(t, "#/components/schemas/UserList", merged.Paths["/a/users"].Get.Responses["200"].Content[oas.ContentTypeJson].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/b_UserList", merged.Paths["/b/users"].Get.Responses["200"].Content[oas.ContentTypeJson].Schema.Ref)
+	users, _ := merged.Components.Schemas["b_UserList"].PropertySchema("users")
+	assert.Equal(t, "#/components/schemas/b_User", users.Items.Ref)
+	assert.Len(t, merged.Components.Schemas, 5, "the identical Error schema is kept once")
+	assert.Equal(t, []oas.TagObject{{Name: "users"}}, merged.Tags)
+	assert.Equal(t, []Rename{
+		{Service: "b", Kind: "schemas", From: "User", To: "b_User"},
+		{Service: "b", Kind: "schemas", From: "UserList", To: "b_UserList"},
+	}, report.Renames)
+	assert.Equal(t, []Collision{{Kind: CollisionOperationID, Name: "ListUsers", Services: []string{"a", "b"}}}, report.Collisions)
+}
+
+func Test_Merge_PathCollision(t *testing.T) {
+	_, report, err := Merge([]Spec{{Service: "a", OpenAPI: serviceSpec("name")}, {Service: "b", OpenAPI: serviceSpec("name")}})
+
+	assert.NoError(t, err)
+	assert.Empty(t, report.Renames)
+	assert.Equal(t, []Collision{{Kind: CollisionPath, Name: "get /users", Services: []string{"a", "b"}}}, report.Collisions)
+}
+
+func Test_Merge_Tags(t *testing.T) {
+	first := serviceSpec("name")
+	second := serviceSpec("email")
+	second.Tags = []oas.TagObject{{Name: "users", Description: "The users"}, {Name: "orders"}}
+
+	merged, _, err := Merge([]Spec{{Service: "a", Prefix: "/a", OpenAPI: first}, {Service: "b", Prefix: "/b", OpenAPI: second}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []oas.TagObject{{Name: "users", Description: "The users"}, {Name: "orders"}}, merged.Tags)
+	assert.Equal(t, []oas.TagObject{{Name: "users"}}, first.Tags, "the input specs are unchanged")
+	assert.Equal(t, []oas.TagObject{{Name: "users", Description: "The users"}, {Name: "orders"}}, second.Tags)
+}
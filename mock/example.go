@@ -0,0 +1,113 @@
+package mock
+
+import (
+	"github.com/iancoleman/orderedmap"
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+)
+
+// Example builds a sample value for a schema. The `example` of the schema is used when present,
+// otherwise a value is synthesised from the type, format, enum and limits of the schema.
+func Example(openAPI *oas.OpenAPIObject, schema *oas.SchemaObject) interface{} {
+	g := exampleGenerator{openAPI: openAPI, visiting: map[string]bool{}}
+	return g.example(schema)
+}
+
+type exampleGenerator struct {
+	openAPI  *oas.OpenAPIObject
+	visiting map[string]bool
+}
+
+func (g *exampleGenerator) example(schema *oas.SchemaObject) interface{} {
+	if schema == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		// a recursive type is cut at the first back reference
+		if g.visiting[schema.Ref] {
+			return nil
+		}
+		resolved, ok := g.openAPI.SchemaByRef(schema.Ref)
+		if !ok {
+			return nil
+		}
+		g.visiting[schema.Ref] = true
+		defer delete(g.visiting, schema.Ref)
+		return g.example(resolved)
+	}
+	if schema.Example != nil {
+		return schema.Example
+	}
+	if enum, ok := schema.Enum.([]interface{}); ok && len(enum) > 0 {
+		return enum[0]
+	}
+
+	switch schema.Type {
+	case "string":
+		return stringExample(schema)
+	case "integer":
+		return int64(numberExample(schema))
+	case "number":
+		return numberExample(schema)
+	case "boolean":
+		return true
+	case "array":
+		return []interface{}{g.example(schema.Items)}
+	}
+	return g.objectExample(schema)
+}
+
+func (g *exampleGenerator) objectExample(schema *oas.SchemaObject) interface{} {
+	object := orderedmap.New()
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		object.Set("key", g.example(schema.AdditionalProperties.Schema))
+	}
+	if schema.Properties == nil {
+		return object
+	}
+	for _, name := range schema.Properties.Keys() {
+		propertySchema, ok := schema.PropertySchema(name)
+		if !ok {
+			continue
+		}
+		object.Set(name, g.example(propertySchema))
+	}
+	return object
+}
+
+func stringExample(schema *oas.SchemaObject) string {
+	switch schema.Format {
+	case "date-time":
+		return "2021-01-01T00:00:00Z"
+	case "date":
+		return "2021-01-01"
+	case "uuid":
+		return "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	case "email":
+		return "user@example.com"
+	case "uri", "url":
+		return "https://example.com"
+	case "binary", "byte":
+		return ""
+	}
+	example := "string"
+	for uint(len(example)) < schema.MinLength {
+		example += "string"
+	}
+	if schema.MaxLength != 0 && uint(len(example)) > schema.MaxLength {
+		example = example[:schema.MaxLength]
+	}
+	return example
+}
+
+func numberExample(schema *oas.SchemaObject) float64 {
+	if schema.Minimum != 0 || schema.ExclusiveMinimum {
+		if schema.ExclusiveMinimum {
+			return schema.Minimum + 1
+		}
+		return schema.Minimum
+	}
+	if schema.Maximum < 0 || (schema.Maximum == 0 && schema.ExclusiveMaximum) {
+		return schema.Maximum - 1
+	}
+	return 0
+}
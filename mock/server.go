@@ -0,0 +1,149 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/validator"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// StatusHeader lets a caller pick one of the documented responses, eg. "X-Mock-Status: 400"
+	StatusHeader = "X-Mock-Status"
+	// StatusQueryParam does the same as StatusHeader from the query string, eg. "?__status=400"
+	StatusQueryParam = "__status"
+
+	defaultStatus = "default"
+)
+
+type Server struct {
+	openAPI   *oas.OpenAPIObject
+	router    *validator.Router
+	validator *validator.Validator
+}
+
+// NewServer returns an http.Handler answering every documented operation with its examples
+func NewServer(openAPI *oas.OpenAPIObject) *Server {
+	return &Server{
+		openAPI:   openAPI,
+		router:    validator.NewRouter(openAPI),
+		validator: validator.New(openAPI),
+	}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	route, err := s.router.FindRoute(r.Method, r.URL.Path)
+	if err != nil {
+		status := http.StatusNotFound
+		if err == validator.ErrMethodNotAllowed {
+			status = http.StatusMethodNotAllowed
+		}
+		validator.WriteErrors(w, status, err)
+		return
+	}
+	log.Infof("mock: %s %s -> %s %s", r.Method, r.URL.Path, route.Method, route.Path)
+
+	status, err := s.selectStatus(r, route.Operation)
+	if err != nil {
+		validator.WriteErrors(w, http.StatusBadRequest, err)
+		return
+	}
+	// an explicitly requested failure is returned as it is, even for an invalid request
+	if !isExplicitStatus(r) {
+		if err := s.validator.ValidateRequest(r, route); err != nil {
+			validator.WriteErrors(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	response, ok := route.Operation.Responses[status]
+	if !ok {
+		response = route.Operation.Responses[defaultStatus]
+	}
+	s.writeResponse(w, statusCode(status), response)
+}
+
+func (s *Server) selectStatus(r *http.Request, operation *oas.OperationObject) (string, error) {
+	if requested := requestedStatus(r); requested != "" {
+		if _, ok := operation.Responses[requested]; ok {
+			return requested, nil
+		}
+		// the default response answers the statuses which are not documented
+		if _, ok := operation.Responses[defaultStatus]; ok && isStatusCode(requested) {
+			return requested, nil
+		}
+		return "", fmt.Errorf("status %s is not documented for this operation", requested)
+	}
+	if len(operation.Responses) == 0 {
+		return "", fmt.Errorf("operation has no documented responses")
+	}
+	statuses := make([]string, 0, len(operation.Responses))
+	for status := range operation.Responses {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		if strings.HasPrefix(status, "2") {
+			return status, nil
+		}
+	}
+	return statuses[0], nil
+}
+
+func requestedStatus(r *http.Request) string {
+	if status := r.Header.Get(StatusHeader); status != "" {
+		return status
+	}
+	return r.URL.Query().Get(StatusQueryParam)
+}
+
+func isExplicitStatus(r *http.Request) bool {
+	status, err := strconv.Atoi(requestedStatus(r))
+	return err == nil && status >= http.StatusBadRequest
+}
+
+// statusCode is the http status of a documented response, the first one of a range, eg. 200 for
+// 2XX, and 500 for the default response
+func statusCode(status string) int {
+	if isStatusCode(status) {
+		code, _ := strconv.Atoi(status)
+		return code
+	}
+	if len(status) == 3 && strings.ToUpper(status[1:]) == "XX" && status[0] >= '1' && status[0] <= '5' {
+		return int(status[0]-'0') * 100
+	}
+	return http.StatusInternalServerError
+}
+
+func isStatusCode(status string) bool {
+	code, err := strconv.Atoi(status)
+	return err == nil && code >= 100 && code <= 599
+}
+
+func (s *Server) writeResponse(w http.ResponseWriter, statusCode int, response *oas.ResponseObject) {
+	if response == nil || len(response.Content) == 0 {
+		w.WriteHeader(statusCode)
+		return
+	}
+	if mediaType, ok := response.Content[oas.ContentTypeJson]; ok {
+		w.Header().Set("Content-Type", oas.ContentTypeJson)
+		w.WriteHeader(statusCode)
+		_ = json.NewEncoder(w).Encode(Example(s.openAPI, &mediaType.Schema))
+		return
+	}
+	contentTypes := make([]string, 0, len(response.Content))
+	for contentType := range response.Content {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+	w.Header().Set("Content-Type", contentTypes[0])
+	w.WriteHeader(statusCode)
+	if mediaType := response.Content[contentTypes[0]]; mediaType != nil {
+		_, _ = fmt.Fprint(w, Example(s.openAPI, &mediaType.Schema))
+	}
+}
@@ -0,0 +1,205 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/iancoleman/orderedmap"
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MockServer(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		header         map[string]string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Should respond with the example of the success response",
+			method:         http.MethodGet,
+			target:         "/users/10",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":100,"name":"Parvez"}`,
+		},
+		{
+			name:           "Should reject path params not matching the schema",
+			method:         http.MethodGet,
+			target:         "/users/abc",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"errors":["path id: expected integer but got \"abc\""]}`,
+		},
+		{
+			name:           "Should respond with the documented failure picked by header",
+			method:         http.MethodGet,
+			target:         "/users/10",
+			header:         map[string]string{StatusHeader: "400"},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"code":"string"}`,
+		},
+		{
+			name:           "Should respond with the documented failure picked by query",
+			method:         http.MethodGet,
+			target:         "/users/10?__status=400",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"code":"string"}`,
+		},
+		{
+			name:           "Should reject undocumented status",
+			method:         http.MethodGet,
+			target:         "/users/10?__status=500",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"errors":["status 500 is not documented for this operation"]}`,
+		},
+		{
+			name:           "Should validate request body",
+			method:         http.MethodPost,
+			target:         "/users",
+			body:           `{"name":"Pa"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"errors":["body id: is required","body name: length must be at least 3"]}`,
+		},
+		{
+			name:           "Should accept valid request body",
+			method:         http.MethodPost,
+			target:         "/users",
+			body:           `{"id":1,"name":"Parvez"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":100,"name":"Parvez"}`,
+		},
+		{
+			name:           "Should return method not allowed for undocumented method",
+			method:         http.MethodDelete,
+			target:         "/users",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedBody:   `{"errors":["method not allowed"]}`,
+		},
+		{
+			name:           "Should return not found for undocumented path",
+			method:         http.MethodGet,
+			target:         "/restaurants",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"errors":["path not found"]}`,
+		},
+	}
+	server := NewServer(getMockOpenAPIObject())
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(test.method, test.target, strings.NewReader(test.body))
+			for key, value := range test.header {
+				request.Header.Set(key, value)
+			}
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, request)
+
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+			assert.JSONEq(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func Test_MockServerStatusKeywords(t *testing.T) {
+	text := &oas.MediaTypeObject{Schema: oas.SchemaObject{Type: "string", Example: "id,name"}}
+	openAPI := &oas.OpenAPIObject{Paths: oas.PathsObject{
+		"/exports": {
+			Get: &oas.OperationObject{
+				Responses: oas.ResponsesObject{
+					"2XX":     {Description: "Export", Content: map[string]*oas.MediaTypeObject{"text/plain": text, "text/csv": text}},
+					"default": {Description: "Error", Content: map[string]*oas.MediaTypeObject{"text/plain": text}},
+				},
+			},
+		},
+	}}
+	tests := []struct {
+		name                string
+		status              string
+		expectedStatus      int
+		expectedContentType string
+	}{
+		{name: "Should answer a range with its first status", expectedStatus: http.StatusOK, expectedContentType: "text/csv"},
+		{name: "Should answer the default response with 500", status: "default", expectedStatus: http.StatusInternalServerError, expectedContentType: "text/plain"},
+		{name: "Should answer an undocumented status with the default response", status: "418", expectedStatus: http.StatusTeapot, expectedContentType: "text/plain"},
+	}
+	server := NewServer(openAPI)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/exports", nil)
+			if test.status != "" {
+				request.Header.Set(StatusHeader, test.status)
+			}
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, request)
+
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+			assert.Equal(t, test.expectedContentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, "id,name", recorder.Body.String())
+		})
+	}
+}
+
+func Test_ExampleShouldCutRecursiveTypes(t *testing.T) {
+	properties := orderedmap.New()
+	properties.Set("children", &oas.SchemaObject{Type: "array", Items: &oas.SchemaObject{Ref: "#/components/schemas/Node"}})
+	openAPI := &oas.OpenAPIObject{Components: oas.ComponentsObject{Schemas: map[string]*oas.SchemaObject{
+		"Node": {Type: "object", Properties: properties},
+	}}}
+
+	example, err := json.Marshal(Example(openAPI, &oas.SchemaObject{Ref: "#/components/schemas/Node"}))
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"children":[null]}`, string(example))
+}
+
+func getMockOpenAPIObject() *oas.OpenAPIObject {
+	userProperties := orderedmap.New()
+	userProperties.Set("id", &oas.SchemaObject{Type: "integer", Example: 100})
+	userProperties.Set("name", &oas.SchemaObject{Type: "string", Example: "Parvez", MinLength: 3})
+	errorProperties := orderedmap.New()
+	errorProperties.Set("code", &oas.SchemaObject{Type: "string"})
+
+	userResponse := map[string]*oas.MediaTypeObject{
+		oas.ContentTypeJson: {Schema: oas.SchemaObject{Ref: "#/components/schemas/User"}},
+	}
+	errorResponse := map[string]*oas.MediaTypeObject{
+		oas.ContentTypeJson: {Schema: oas.SchemaObject{Ref: "#/components/schemas/Error"}},
+	}
+	return &oas.OpenAPIObject{
+		Paths: oas.PathsObject{
+			"/users/{id}": {
+				Get: &oas.OperationObject{
+					Parameters: []oas.ParameterObject{
+						{Name: "id", In: "path", Required: true, Schema: &oas.SchemaObject{Type: "integer"}},
+					},
+					Responses: oas.ResponsesObject{
+						"200": {Description: "User", Content: userResponse},
+						"400": {Description: "Error", Content: errorResponse},
+					},
+				},
+			},
+			"/users": {
+				Post: &oas.OperationObject{
+					RequestBody: &oas.RequestBodyObject{
+						Required: true,
+						Content:  userResponse,
+					},
+					Responses: oas.ResponsesObject{
+						"200": {Description: "User", Content: userResponse},
+					},
+				},
+			},
+		},
+		Components: oas.ComponentsObject{
+			Schemas: map[string]*oas.SchemaObject{
+				"User":  {Type: "object", Properties: userProperties, Required: []string{"id", "name"}},
+				"Error": {Type: "object", Properties: errorProperties},
+			},
+		},
+	}
+}
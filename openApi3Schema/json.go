@@ -0,0 +1,77 @@
+package openApi3Schema
+
+import (
+	"encoding/json"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// UnmarshalJSON keeps the order of the properties and decodes every property into a *SchemaObject,
+// the same shape the parser builds.
+func (s *SchemaObject) UnmarshalJSON(data []byte) error {
+	type schemaAlias SchemaObject
+	aux := struct {
+		*schemaAlias
+		Properties json.RawMessage `json:"properties,omitempty"`
+	}{schemaAlias: (*schemaAlias)(s)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if len(aux.Properties) == 0 || string(aux.Properties) == "null" {
+		s.Properties = nil
+		return nil
+	}
+
+	order := orderedmap.New()
+	if err := json.Unmarshal(aux.Properties, order); err != nil {
+		return err
+	}
+	properties := map[string]*SchemaObject{}
+	if err := json.Unmarshal(aux.Properties, &properties); err != nil {
+		return err
+	}
+	s.Properties = orderedmap.New()
+	for _, key := range order.Keys() {
+		s.Properties.Set(key, properties[key])
+	}
+	return nil
+}
+
+// AdditionalProperties is either a bool telling if an object allows other properties than its
+// properties, or the schema of the other properties, eg. the values of a map
+type AdditionalProperties struct {
+	Allowed bool
+	Schema  *SchemaObject
+}
+
+// AllowAdditionalProperties returns additional properties allowed or not
+func AllowAdditionalProperties(allowed bool) *AdditionalProperties {
+	return &AdditionalProperties{Allowed: allowed}
+}
+
+// AdditionalPropertiesOf returns additional properties of a schema
+func AdditionalPropertiesOf(schema *SchemaObject) *AdditionalProperties {
+	return &AdditionalProperties{Allowed: true, Schema: schema}
+}
+
+// IsAllowed tells if other properties are allowed, which they are by default
+func (a *AdditionalProperties) IsAllowed() bool {
+	return a == nil || a.Allowed || a.Schema != nil
+}
+
+func (a AdditionalProperties) MarshalJSON() ([]byte, error) {
+	if a.Schema != nil {
+		return json.Marshal(a.Schema)
+	}
+	return json.Marshal(a.Allowed)
+}
+
+func (a *AdditionalProperties) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.Allowed); err == nil {
+		a.Schema = nil
+		return nil
+	}
+	a.Schema = &SchemaObject{}
+	a.Allowed = true
+	return json.Unmarshal(data, a.Schema)
+}
@@ -18,8 +18,15 @@ type OpenAPIObject struct {
 
 	Components ComponentsObject      `json:"components,omitempty"` // Required for Authorization header
 	Security   []map[string][]string `json:"security,omitempty"`
+	Tags       []TagObject           `json:"tags,omitempty"`
+
+	// ExternalDocs
+}
+
+type TagObject struct {
+	Name        string `json:"name"` // Required
+	Description string `json:"description,omitempty"`
 
-	// Tags
 	// ExternalDocs
 }
 
@@ -129,6 +136,9 @@ type SchemaObject struct {
 	PkgName              string                 `json:"-"` // For go-swagger3
 	FieldName            string                 `json:"-"` // For go-swagger3
 	DisabledFieldNames   map[string]struct{}    `json:"-"` // For go-swagger3
+	StructFields         map[string]StructField `json:"-"` // For go-swagger3
+	RequestRequired      []string               `json:"-"` // For go-swagger3, required in the request bodies only
+	ResponseRequired     []string               `json:"-"` // For go-swagger3, required in the responses only
 	Type                 string                 `json:"type,omitempty"`
 	Format               string                 `json:"format,omitempty"`
 	Required             []string               `json:"required,omitempty"`
@@ -152,19 +162,52 @@ type SchemaObject struct {
 	UniqueItems          bool                   `json:"uniqueItems,omitempty"`
 	MaxProperties        uint                   `json:"maxProperties,omitempty"`
 	MinProperties        uint                   `json:"minProperties,omitempty"`
-	AdditionalProperties bool                   `json:"additionalProperties,omitempty"`
+	AdditionalProperties *AdditionalProperties  `json:"additionalProperties,omitempty"`
 	Nullable             bool                   `json:"nullable,omitempty"`
 	ReadOnly             bool                   `json:"readOnly,omitempty"`
 	WriteOnly            bool                   `json:"writeOnly,omitempty"`
+	KeyType              string                 `json:"x-key-type,omitempty"` // go type of the keys of a map other than string
+	Default              interface{}            `json:"default,omitempty"`
+	MultipleOf           float64                `json:"multipleOf,omitempty"`
+	Not                  *SchemaObject          `json:"not,omitempty"`
+	XML                  *XMLObject             `json:"xml,omitempty"`
+	ExternalDocs         *ExternalDocsObject    `json:"externalDocs,omitempty"`
+	Discriminator        *DiscriminatorObject   `json:"discriminator,omitempty"`
 
-	// MultipleOf
 	// AllOf
 	// OneOf
 	// AnyOf
-	// Not
-	// Default
-	// XML
-	// ExternalDocs
+}
+
+// XMLObject tells how a property is written in xml
+type XMLObject struct {
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Prefix    string `json:"prefix,omitempty"`
+	Attribute bool   `json:"attribute,omitempty"`
+	Wrapped   bool   `json:"wrapped,omitempty"`
+}
+
+type ExternalDocsObject struct {
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"` // Required
+}
+
+// DiscriminatorObject names the property telling which schema a value is, the mapping maps its
+// values to the schemas when they are not the names of the schemas
+type DiscriminatorObject struct {
+	PropertyName string            `json:"propertyName"` // Required
+	Mapping      map[string]string `json:"mapping,omitempty"`
+}
+
+// StructField tells how encoding/json finds a property of a struct, through how many embedded structs
+// and among how many fields of the same name
+type StructField struct {
+	// Depth is 0 for the fields of the struct, 1 for the fields of its embedded structs and so on
+	Depth int
+	// Tagged and Untagged count the fields of the name at this depth, up to 2: the property is
+	// dropped unless a single one is tagged or a single one is found
+	Tagged, Untagged int
 }
 
 type ResponsesObject map[string]*ResponseObject // [status]ResponseObject
@@ -0,0 +1,247 @@
+package openApi3Schema
+
+import (
+	"net/http"
+	"strings"
+)
+
+const (
+	SchemaRefPrefix    = "#/components/schemas/"
+	ParameterRefPrefix = "#/components/parameters/"
+)
+
+// Methods lists the http methods a PathItemObject can hold, in the order they are documented
+var Methods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPatch,
+	http.MethodPut,
+	http.MethodDelete,
+	http.MethodOptions,
+	http.MethodHead,
+	http.MethodTrace,
+}
+
+// Operation returns the operation registered for the given http method, nil if there is none
+func (p *PathItemObject) Operation(method string) *OperationObject {
+	switch strings.ToUpper(method) {
+	case http.MethodGet:
+		return p.Get
+	case http.MethodPost:
+		return p.Post
+	case http.MethodPatch:
+		return p.Patch
+	case http.MethodPut:
+		return p.Put
+	case http.MethodDelete:
+		return p.Delete
+	case http.MethodOptions:
+		return p.Options
+	case http.MethodHead:
+		return p.Head
+	case http.MethodTrace:
+		return p.Trace
+	}
+	return nil
+}
+
+// SetOperation registers the operation for the given http method
+func (p *PathItemObject) SetOperation(method string, operation *OperationObject) {
+	switch strings.ToUpper(method) {
+	case http.MethodGet:
+		p.Get = operation
+	case http.MethodPost:
+		p.Post = operation
+	case http.MethodPatch:
+		p.Patch = operation
+	case http.MethodPut:
+		p.Put = operation
+	case http.MethodDelete:
+		p.Delete = operation
+	case http.MethodOptions:
+		p.Options = operation
+	case http.MethodHead:
+		p.Head = operation
+	case http.MethodTrace:
+		p.Trace = operation
+	}
+}
+
+// SchemaByRef resolves a "#/components/schemas/..." reference
+func (o *OpenAPIObject) SchemaByRef(ref string) (*SchemaObject, bool) {
+	if !strings.HasPrefix(ref, SchemaRefPrefix) {
+		return nil, false
+	}
+	schema, ok := o.Components.Schemas[strings.TrimPrefix(ref, SchemaRefPrefix)]
+	return schema, ok && schema != nil
+}
+
+// ParameterByRef resolves a "#/components/parameters/..." reference
+func (o *OpenAPIObject) ParameterByRef(ref string) (*ParameterObject, bool) {
+	if !strings.HasPrefix(ref, ParameterRefPrefix) {
+		return nil, false
+	}
+	parameter, ok := o.Components.Parameters[strings.TrimPrefix(ref, ParameterRefPrefix)]
+	return parameter, ok && parameter != nil
+}
+
+// ResolveSchema follows the $ref chain of a schema until it reaches a concrete definition.
+// Unresolvable references are returned as they are.
+func (o *OpenAPIObject) ResolveSchema(schema *SchemaObject) *SchemaObject {
+	seen := map[string]bool{}
+	for schema != nil && schema.Ref != "" && !seen[schema.Ref] {
+		seen[schema.Ref] = true
+		resolved, ok := o.SchemaByRef(schema.Ref)
+		if !ok {
+			return schema
+		}
+		schema = resolved
+	}
+	return schema
+}
+
+// ResolveParameter returns the component parameter a reference points to, or the parameter itself
+func (o *OpenAPIObject) ResolveParameter(parameter *ParameterObject) *ParameterObject {
+	if parameter == nil || parameter.Ref == "" {
+		return parameter
+	}
+	if resolved, ok := o.ParameterByRef(parameter.Ref); ok {
+		return resolved
+	}
+	return parameter
+}
+
+// PropertySchema returns the schema stored for a property of an object schema
+func (s *SchemaObject) PropertySchema(name string) (*SchemaObject, bool) {
+	if s.Properties == nil {
+		return nil, false
+	}
+	value, ok := s.Properties.Get(name)
+	if !ok {
+		return nil, false
+	}
+	schema, ok := value.(*SchemaObject)
+	return schema, ok
+}
+
+// ReachSchemas adds a schema to reached along with the schemas it holds or references, through its
+// properties, its items, its additional properties, its not and its discriminator mapping
+func (o *OpenAPIObject) ReachSchemas(schema *SchemaObject, reached map[*SchemaObject]bool) {
+	if schema == nil || reached[schema] {
+		return
+	}
+	reached[schema] = true
+	if resolved, ok := o.SchemaByRef(schema.Ref); ok {
+		o.ReachSchemas(resolved, reached)
+	}
+	o.ReachSchemas(schema.Items, reached)
+	o.ReachSchemas(schema.Not, reached)
+	if schema.AdditionalProperties != nil {
+		o.ReachSchemas(schema.AdditionalProperties.Schema, reached)
+	}
+	if schema.Discriminator != nil {
+		for _, ref := range schema.Discriminator.Mapping {
+			if resolved, ok := o.SchemaByRef(ref); ok {
+				o.ReachSchemas(resolved, reached)
+			}
+		}
+	}
+	if schema.Properties != nil {
+		for _, name := range schema.Properties.Keys() {
+			if property, ok := schema.PropertySchema(name); ok {
+				o.ReachSchemas(property, reached)
+			}
+		}
+	}
+}
+
+// RenameSchemas renames the component schemas, names maps their old key to the new one, and
+// rewrites the references to them
+func (o *OpenAPIObject) RenameSchemas(names map[string]string) {
+	if len(names) == 0 {
+		return
+	}
+	schemas := make(map[string]*SchemaObject, len(o.Components.Schemas))
+	for key, schema := range o.Components.Schemas {
+		if name, ok := names[key]; ok {
+			key = name
+		}
+		schemas[key] = schema
+	}
+	o.Components.Schemas = schemas
+
+	r := schemaRenamer{names: names, seen: map[*SchemaObject]bool{}}
+	for _, schema := range schemas {
+		r.rename(schema)
+	}
+	for _, parameter := range o.Components.Parameters {
+		if parameter != nil {
+			r.rename(parameter.Schema)
+		}
+	}
+	for _, item := range o.Paths {
+		for _, method := range Methods {
+			op := item.Operation(method)
+			if op == nil {
+				continue
+			}
+			for i := range op.Parameters {
+				r.rename(op.Parameters[i].Schema)
+			}
+			if op.RequestBody != nil {
+				for _, mediaType := range op.RequestBody.Content {
+					if mediaType != nil {
+						r.rename(&mediaType.Schema)
+					}
+				}
+			}
+			for _, response := range op.Responses {
+				if response == nil {
+					continue
+				}
+				for _, mediaType := range response.Content {
+					if mediaType != nil {
+						r.rename(&mediaType.Schema)
+					}
+				}
+			}
+		}
+	}
+}
+
+type schemaRenamer struct {
+	names map[string]string
+	seen  map[*SchemaObject]bool
+}
+
+func (r schemaRenamer) rename(schema *SchemaObject) {
+	if schema == nil || r.seen[schema] {
+		return
+	}
+	r.seen[schema] = true
+	if strings.HasPrefix(schema.Ref, SchemaRefPrefix) {
+		key := strings.TrimPrefix(schema.Ref, SchemaRefPrefix)
+		if name, ok := r.names[key]; ok {
+			schema.Ref = SchemaRefPrefix + name
+		}
+	}
+	r.rename(schema.Items)
+	r.rename(schema.Not)
+	if schema.AdditionalProperties != nil {
+		r.rename(schema.AdditionalProperties.Schema)
+	}
+	if schema.Discriminator != nil {
+		for value, ref := range schema.Discriminator.Mapping {
+			if name, ok := r.names[strings.TrimPrefix(ref, SchemaRefPrefix)]; ok && strings.HasPrefix(ref, SchemaRefPrefix) {
+				schema.Discriminator.Mapping[value] = SchemaRefPrefix + name
+			}
+		}
+	}
+	if schema.Properties != nil {
+		for _, name := range schema.Properties.Keys() {
+			if property, ok := schema.PropertySchema(name); ok {
+				r.rename(property)
+			}
+		}
+	}
+}
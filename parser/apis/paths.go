@@ -52,7 +52,7 @@ func (p *parser) parsePathFromFile(astFile *ast.File, pkgPath string, pkgName st
 func (p *parser) parsePathFromFuncDeclaration(astDeclaration ast.Decl, pkgPath string, pkgName string) error {
 	astFuncDeclaration, ok := astDeclaration.(*ast.FuncDecl)
 	if ok && astFuncDeclaration.Doc != nil && astFuncDeclaration.Doc.List != nil {
-		if err := p.operationParser.Parse(pkgPath, pkgName, astFuncDeclaration.Doc.List); err != nil {
+		if err := p.operationParser.Parse(pkgPath, pkgName, funcScope(astFuncDeclaration), astFuncDeclaration.Doc.List); err != nil {
 			return err
 		}
 	}
@@ -74,6 +74,10 @@ func (p *parser) parseTypeSpecFromDeclaration(astDeclaration ast.Decl, pkgName s
 func (p *parser) parseTypeSpecFromGenDeclaration(astGenDeclaration *ast.GenDecl, pkgName string) {
 	for _, astSpec := range astGenDeclaration.Specs {
 		if typeSpec, ok := astSpec.(*ast.TypeSpec); ok {
+			// the doc of a lone type declaration, eg. its @SchemaName, is the one of the declaration
+			if typeSpec.Doc == nil && len(astGenDeclaration.Specs) == 1 {
+				typeSpec.Doc = astGenDeclaration.Doc
+			}
 			p.TypeSpecs[pkgName][typeSpec.Name.String()] = typeSpec
 			p.parseTypeAlias(typeSpec, pkgName)
 		}
@@ -83,39 +87,89 @@ func (p *parser) parseTypeSpecFromGenDeclaration(astGenDeclaration *ast.GenDecl,
 // parseTypeSpecInFuncDeclaration find type declaration in func, method
 func (p *parser) parseTypeSpecInFuncDeclaration(astFuncDeclaration *ast.FuncDecl, pkgName string) {
 	if astFuncDeclaration.Doc != nil && astFuncDeclaration.Doc.List != nil && astFuncDeclaration.Body != nil {
-		funcName := astFuncDeclaration.Name.String()
+		scope := funcScope(astFuncDeclaration)
 		for _, astStmt := range astFuncDeclaration.Body.List {
-			p.parseTypeSpecFromFunctionBlockStmt(astFuncDeclaration, pkgName, astStmt, funcName)
+			p.parseTypeSpecFromFunctionBlockStmt(pkgName, astStmt, scope)
 		}
 	}
 }
 
-func (p *parser) parseTypeSpecFromFunctionBlockStmt(astFuncDeclaration *ast.FuncDecl, pkgName string, astStmt ast.Stmt, funcName string) {
+// funcScope returns the scope of the types declared in a func, its name, or in a method, the name of
+// its receiver type and its name joined by @
+func funcScope(astFuncDeclaration *ast.FuncDecl) string {
+	funcName := astFuncDeclaration.Name.String()
+	if astFuncDeclaration.Recv == nil || len(astFuncDeclaration.Recv.List) == 0 {
+		return funcName
+	}
+	var recvTypeName string
+	if astStarExpr, ok := astFuncDeclaration.Recv.List[0].Type.(*ast.StarExpr); ok {
+		recvTypeName = fmt.Sprintf("%s", astStarExpr.X)
+	} else if astIdent, ok := astFuncDeclaration.Recv.List[0].Type.(*ast.Ident); ok {
+		recvTypeName = astIdent.String()
+	}
+	return strings.Join([]string{recvTypeName, funcName}, "@")
+}
+
+func (p *parser) parseTypeSpecFromFunctionBlockStmt(pkgName string, astStmt ast.Stmt, scope string) {
 	if astDeclStmt, ok := astStmt.(*ast.DeclStmt); ok {
 		if astGenDeclaration, ok := astDeclStmt.Decl.(*ast.GenDecl); ok {
-			p.parseTypeSpecFromFunctionGenDeclaration(astFuncDeclaration, pkgName, astGenDeclaration, funcName)
+			p.parseTypeSpecFromFunctionGenDeclaration(pkgName, astGenDeclaration, scope)
+		}
+	} else if astAssignStmt, ok := astStmt.(*ast.AssignStmt); ok && astAssignStmt.Tok == token.DEFINE {
+		// response := struct{...}{...}
+		for i, astExpr := range astAssignStmt.Rhs {
+			if i >= len(astAssignStmt.Lhs) {
+				break
+			}
+			astIdent, ok := astAssignStmt.Lhs[i].(*ast.Ident)
+			if !ok {
+				continue
+			}
+			if astUnaryExpr, ok := astExpr.(*ast.UnaryExpr); ok {
+				astExpr = astUnaryExpr.X
+			}
+			if astCompositeLit, ok := astExpr.(*ast.CompositeLit); ok {
+				p.parseAnonymousStructVar(pkgName, astIdent, astCompositeLit.Type, scope)
+			}
 		}
 	}
 }
 
-func (p *parser) parseTypeSpecFromFunctionGenDeclaration(astFuncDeclaration *ast.FuncDecl, pkgName string, astGenDeclaration *ast.GenDecl, funcName string) {
+func (p *parser) parseTypeSpecFromFunctionGenDeclaration(pkgName string, astGenDeclaration *ast.GenDecl, scope string) {
 	for _, astSpec := range astGenDeclaration.Specs {
-		typeSpec, ok := astSpec.(*ast.TypeSpec)
-		if !ok {
-			continue
-		}
-		// type in func
-		if astFuncDeclaration.Recv == nil {
-			p.TypeSpecs[pkgName][strings.Join([]string{funcName, typeSpec.Name.String()}, "@")] = typeSpec
-			continue
-		}
-		// type in method
-		var recvTypeName string
-		if astStarExpr, ok := astFuncDeclaration.Recv.List[0].Type.(*ast.StarExpr); ok {
-			recvTypeName = fmt.Sprintf("%s", astStarExpr.X)
-		} else if astIdent, ok := astFuncDeclaration.Recv.List[0].Type.(*ast.Ident); ok {
-			recvTypeName = astIdent.String()
+		if typeSpec, ok := astSpec.(*ast.TypeSpec); ok {
+			p.TypeSpecs[pkgName][strings.Join([]string{scope, typeSpec.Name.String()}, "@")] = typeSpec
+		} else if valueSpec, ok := astSpec.(*ast.ValueSpec); ok {
+			// var response struct{...}
+			for _, astIdent := range valueSpec.Names {
+				p.parseAnonymousStructVar(pkgName, astIdent, valueSpec.Type, scope)
+			}
 		}
-		p.TypeSpecs[pkgName][strings.Join([]string{recvTypeName, funcName, typeSpec.Name.String()}, "@")] = typeSpec
 	}
 }
+
+// parseAnonymousStructVar declares the anonymous struct, or slice or map of them, of a variable
+// declared in a func as a type of the variable name
+func (p *parser) parseAnonymousStructVar(pkgName string, astIdent *ast.Ident, astType ast.Expr, scope string) {
+	if astStarExpr, ok := astType.(*ast.StarExpr); ok {
+		astType = astStarExpr.X
+	}
+	if !isAnonymousStruct(astType) || astIdent.Name == "_" {
+		return
+	}
+	p.TypeSpecs[pkgName][strings.Join([]string{scope, astIdent.Name}, "@")] = &ast.TypeSpec{Name: astIdent, Type: astType}
+}
+
+func isAnonymousStruct(astType ast.Expr) bool {
+	switch astType := astType.(type) {
+	case *ast.StarExpr:
+		return isAnonymousStruct(astType.X)
+	case *ast.ArrayType:
+		return isAnonymousStruct(astType.Elt)
+	case *ast.MapType:
+		return isAnonymousStruct(astType.Value)
+	case *ast.StructType:
+		return true
+	}
+	return false
+}
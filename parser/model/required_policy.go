@@ -0,0 +1,64 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// RequiredExplicit makes the fields tagged required:"true" or json:",required" required, it always applies
+	RequiredExplicit = "explicit"
+	// RequiredValidate makes the fields tagged validate:"required" or binding:"required" required
+	RequiredValidate = "validate"
+	// RequiredNonPointer makes the fields other than pointers and without omitempty required, the
+	// ones encoding/json always writes
+	RequiredNonPointer = "non-pointer"
+
+	requestDirection  = "request"
+	responseDirection = "response"
+)
+
+// RequiredPolicy is the rules inferring the required properties in the request bodies and in the
+// responses
+type RequiredPolicy struct {
+	Request  map[string]bool
+	Response map[string]bool
+}
+
+// ParseRequiredPolicy returns the policy of a comma separated list of rules, each one applying to the
+// request bodies and the responses, or to one of them when prefixed with request: or response:, eg.
+// validate,response:non-pointer
+func ParseRequiredPolicy(policy string) (RequiredPolicy, error) {
+	requiredPolicy := RequiredPolicy{
+		Request:  map[string]bool{RequiredExplicit: true},
+		Response: map[string]bool{RequiredExplicit: true},
+	}
+	for _, rule := range strings.Split(policy, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		direction := ""
+		if i := strings.Index(rule, ":"); i != -1 {
+			direction, rule = rule[:i], rule[i+1:]
+		}
+		switch rule {
+		case RequiredExplicit, RequiredValidate, RequiredNonPointer:
+		default:
+			return RequiredPolicy{}, fmt.Errorf("unknown required rule %s, expected %s, %s or %s", rule,
+				RequiredExplicit, RequiredValidate, RequiredNonPointer)
+		}
+		switch direction {
+		case "":
+			requiredPolicy.Request[rule], requiredPolicy.Response[rule] = true, true
+		case requestDirection:
+			requiredPolicy.Request[rule] = true
+		case responseDirection:
+			requiredPolicy.Response[rule] = true
+		default:
+			return RequiredPolicy{}, fmt.Errorf("unknown direction %s of the required rule %s, expected %s or %s",
+				direction, rule, requestDirection, responseDirection)
+		}
+	}
+	return requiredPolicy, nil
+}
@@ -0,0 +1,146 @@
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+)
+
+var (
+	majorVersionRegexp  = regexp.MustCompile(`^v[0-9]+$`)
+	versionSuffixRegexp = regexp.MustCompile(`^(.+)\.v[0-9]+$`)
+)
+
+// TypeMapping is the schema a go type is documented with instead of the one of its structure
+type TypeMapping struct {
+	Type     string      `json:"type,omitempty"`
+	Format   string      `json:"format,omitempty"`
+	Pattern  string      `json:"pattern,omitempty"`
+	Example  interface{} `json:"example,omitempty"`
+	Nullable bool        `json:"nullable,omitempty"`
+}
+
+// DefaultTypeMappings are the schemas of the common go types marshalled differently than their structure,
+// by their import path and name
+var DefaultTypeMappings = map[string]TypeMapping{
+	"[]byte":                                {Type: "string", Format: "byte"},
+	"time.Duration":                         {Type: "integer", Format: "int64", Example: 1000000000},
+	"encoding/json.RawMessage":              {},
+	"net.IP":                                {Type: "string", Example: "192.168.0.1"},
+	"net/url.URL":                           {Type: "string", Format: "uri", Example: "https://example.com"},
+	"github.com/google/uuid.UUID":           {Type: "string", Format: "uuid", Example: "3fa85f64-5717-4562-b3fc-2c963f66afa6"},
+	"github.com/gofrs/uuid.UUID":            {Type: "string", Format: "uuid", Example: "3fa85f64-5717-4562-b3fc-2c963f66afa6"},
+	"github.com/satori/go.uuid.UUID":        {Type: "string", Format: "uuid", Example: "3fa85f64-5717-4562-b3fc-2c963f66afa6"},
+	"github.com/shopspring/decimal.Decimal": {Type: "string", Pattern: `^-?[0-9]+(\.[0-9]+)?$`, Example: "12.50"},
+	"google.golang.org/protobuf/types/known/timestamppb.Timestamp": {Type: "string", Format: "date-time"},
+	"github.com/golang/protobuf/ptypes/timestamp.Timestamp":        {Type: "string", Format: "date-time"},
+	"google.golang.org/protobuf/types/known/durationpb.Duration":   {Type: "string", Example: "1.5s"},
+	"database/sql.NullString":                                      {Type: "string", Nullable: true},
+	"database/sql.NullInt64":                                       {Type: "integer", Format: "int64", Nullable: true},
+	"database/sql.NullInt32":                                       {Type: "integer", Format: "int32", Nullable: true},
+	"database/sql.NullFloat64":                                     {Type: "number", Format: "double", Nullable: true},
+	"database/sql.NullBool":                                        {Type: "boolean", Nullable: true},
+	"database/sql.NullTime":                                        {Type: "string", Format: "date-time", Nullable: true},
+	"gopkg.in/guregu/null.v4.String":                               {Type: "string", Nullable: true},
+	"gopkg.in/guregu/null.v4.Int":                                  {Type: "integer", Format: "int64", Nullable: true},
+	"gopkg.in/guregu/null.v4.Float":                                {Type: "number", Format: "double", Nullable: true},
+	"gopkg.in/guregu/null.v4.Bool":                                 {Type: "boolean", Nullable: true},
+	"gopkg.in/guregu/null.v4.Time":                                 {Type: "string", Format: "date-time", Nullable: true},
+	"gopkg.in/guregu/null.v3.String":                               {Type: "string", Nullable: true},
+	"gopkg.in/guregu/null.v3.Int":                                  {Type: "integer", Format: "int64", Nullable: true},
+	"gopkg.in/guregu/null.v3.Float":                                {Type: "number", Format: "double", Nullable: true},
+	"gopkg.in/guregu/null.v3.Bool":                                 {Type: "boolean", Nullable: true},
+	"gopkg.in/guregu/null.v3.Time":                                 {Type: "string", Format: "date-time", Nullable: true},
+}
+
+// Schema returns a new schema of the mapping
+func (m TypeMapping) Schema() *oas.SchemaObject {
+	return &oas.SchemaObject{
+		Type:     m.Type,
+		Format:   m.Format,
+		Pattern:  m.Pattern,
+		Example:  m.Example,
+		Nullable: m.Nullable,
+	}
+}
+
+// LoadTypeMappings returns the default type mappings along with the ones of a yaml or json file, eg.
+//
+//	github.com/user/money.Amount:
+//	  type: string
+//	  pattern: ^[0-9]+\.[0-9]{2}$
+func LoadTypeMappings(path string) (map[string]TypeMapping, error) {
+	mappings := make(map[string]TypeMapping, len(DefaultTypeMappings))
+	for goType, mapping := range DefaultTypeMappings {
+		mappings[goType] = mapping
+	}
+	if path == "" {
+		return mappings, nil
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Can not read the type mapping file %s: %v", path, err)
+	}
+	var fileMappings map[string]TypeMapping
+	if err := yaml.Unmarshal(content, &fileMappings); err != nil {
+		return nil, fmt.Errorf("Can not parse the type mapping file %s: %v", path, err)
+	}
+	for goType, mapping := range fileMappings {
+		mappings[goType] = mapping
+	}
+	return mappings, nil
+}
+
+// TypeMapping returns the mapping of a type named in a package, eg. uuid.UUID, along with its import
+// path and name
+func (p *PkgAndSpecs) TypeMapping(pkgName, typeName string) (mapping *TypeMapping, importPath, name string, ok bool) {
+	if p == nil || len(p.TypeMappings) == 0 {
+		return nil, "", "", false
+	}
+	dot := strings.LastIndex(typeName, ".")
+	// the standard packages, eg. time.Duration, are known by their name
+	if found, ok := p.TypeMappings[typeName]; ok {
+		if dot == -1 {
+			return &found, "", typeName, true
+		}
+		return &found, typeName[:dot], typeName[dot+1:], true
+	}
+	if dot == -1 {
+		if found, ok := p.TypeMappings[pkgName+"."+typeName]; ok {
+			return &found, pkgName, typeName, true
+		}
+		return nil, "", "", false
+	}
+	qualifier, name := typeName[:dot], typeName[dot+1:]
+	for alias, importPaths := range p.PkgNameImportedPkgAlias[pkgName] {
+		for _, importPath := range importPaths {
+			// the alias of an import is the last segment of its path, which is not always the package name
+			if alias != qualifier && packageName(importPath) != qualifier {
+				continue
+			}
+			if found, ok := p.TypeMappings[importPath+"."+name]; ok {
+				return &found, importPath, name, true
+			}
+		}
+	}
+	return nil, "", "", false
+}
+
+// packageName returns the name a package is usually declared with, the last segment of its import path
+// without its version or go. prefix, eg. null for gopkg.in/guregu/null.v4, pkg for
+// github.com/user/pkg/v2 and uuid for github.com/satori/go.uuid
+func packageName(importPath string) string {
+	segments := strings.Split(importPath, "/")
+	name := segments[len(segments)-1]
+	if len(segments) > 1 && majorVersionRegexp.MatchString(name) {
+		name = segments[len(segments)-2]
+	}
+	if matches := versionSuffixRegexp.FindStringSubmatch(name); matches != nil {
+		name = matches[1]
+	}
+	return strings.TrimPrefix(name, "go.")
+}
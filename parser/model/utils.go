@@ -4,6 +4,7 @@ import (
 	"github.com/parvez3019/go-swagger3/logger"
 	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
 	"go/ast"
+	"go/token"
 )
 
 type Utils struct {
@@ -28,6 +29,13 @@ type PkgAndSpecs struct {
 	KnownNamePkg  map[string]*Pkg
 	KnownPathPkg  map[string]*Pkg
 	KnownIDSchema map[string]*oas.SchemaObject
+	// KnownIDType is the go type every schema id is parsed from
+	KnownIDType map[string]*Type
+	FileSet     *token.FileSet
+	// TypeMappings is the schema of the go types documented as another type, by their import path and name
+	TypeMappings map[string]TypeMapping
+	// RequiredRules is the policy of Flags.RequiredPolicy
+	RequiredRules RequiredPolicy
 
 	TypeSpecs               map[string]map[string]*ast.TypeSpec
 	PkgPathAstPkgCache      map[string]map[string]*ast.Package
@@ -38,9 +46,34 @@ type Flags struct {
 	RunInDebugMode   bool
 	RunInStrictMode  bool
 	SchemaWithoutPkg bool
+	SchemaCollision  string
+	SchemaNaming     string
+	// SchemaNames is the schema name of go types, by their package and name, eg. model.User
+	SchemaNames map[string]string
+	// TypeMappingFile is a yaml or json file of type mappings completing DefaultTypeMappings
+	TypeMappingFile string
+	// HoistInlineStructs makes components of the anonymous structs, named after their parent and field
+	HoistInlineStructs bool
+	// JSONNaming is how the struct fields are named, encoding/json's rules by default
+	JSONNaming string
+	// RequiredPolicy is the rules inferring the required properties, see ParseRequiredPolicy
+	RequiredPolicy string
+	// DirectionVariants splits the schemas differing between the requests and the responses
+	DirectionVariants bool
 }
 
 type Pkg struct {
 	Name string
 	Path string
 }
+
+// Type is a go type a schema is parsed from
+type Type struct {
+	PkgName  string
+	Name     string
+	Position token.Position
+	// SchemaName is the name given by its @SchemaName annotation
+	SchemaName string
+	// Of is the id of the schema a request variant is made of, its explicit name is suffixed too
+	Of string
+}
@@ -0,0 +1,321 @@
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	. "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/parser/model"
+	"github.com/parvez3019/go-swagger3/parser/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// SchemaCollisionRename names the schemas of the go types sharing a name after the shortest
+	// suffix of their package telling them apart, eg. billing.Error and auth.Error
+	SchemaCollisionRename = "rename"
+	// SchemaCollisionFail fails the parsing with the source locations of the go types sharing a name
+	SchemaCollisionFail = "fail"
+
+	// SchemaNamingFull names the schemas after the import path of their package, eg. github.com.user.model.User
+	SchemaNamingFull = "full"
+	// SchemaNamingPkg names the schemas after the last segment of their package, eg. model.User
+	SchemaNamingPkg = "pkg"
+	// SchemaNamingType names the schemas after their type only, eg. User
+	SchemaNamingType = "type"
+)
+
+// schemaNameData is what a schema naming template is executed with
+type schemaNameData struct {
+	// Pkg is the last segment of the package, eg. model
+	Pkg string
+	// PkgPath is the import path of the package, eg. github.com/user/model
+	PkgPath string
+	Type    string
+}
+
+var schemaNameFuncs = template.FuncMap{
+	"title": strings.Title,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+}
+
+// SchemaCollision sets what happens when the naming gives the same name to the schemas of several go
+// types, SchemaCollisionRename by default
+func (p *parser) SchemaCollision(mode string) *parser {
+	p.Flags.SchemaCollision = mode
+	return p
+}
+
+// SchemaNaming sets how the schemas are named: SchemaNamingFull, SchemaNamingPkg, SchemaNamingType
+// or a template such as {{.Pkg | title}}{{.Type}}. By default it is SchemaNamingType with
+// --schema-without-pkg, SchemaNamingFull otherwise. names gives the name of go types by their
+// package and name, eg. model.User=PublicUser, and takes precedence over @SchemaName.
+func (p *parser) SchemaNaming(strategy string, names map[string]string) *parser {
+	p.Flags.SchemaNaming = strategy
+	p.Flags.SchemaNames = names
+	return p
+}
+
+// nameSchemas names the component schemas. The schemas are parsed with their package so that the go
+// types sharing a name do not overwrite each other, those are renamed or reported depending on the
+// SchemaCollision mode. The references are renamed too.
+func (p *parser) nameSchemas() error {
+	mode := p.Flags.SchemaCollision
+	if mode == "" {
+		mode = SchemaCollisionRename
+	}
+	if mode != SchemaCollisionRename && mode != SchemaCollisionFail {
+		return fmt.Errorf("unknown schema collision mode %s, expected %s or %s", mode, SchemaCollisionRename, SchemaCollisionFail)
+	}
+	strategy := p.Flags.SchemaNaming
+	if strategy == "" && p.SchemaWithoutPkg {
+		strategy = SchemaNamingType
+	} else if strategy == "" {
+		strategy = SchemaNamingFull
+	}
+	schemaName, err := schemaNamer(strategy)
+	if err != nil {
+		return err
+	}
+
+	schemas := p.OpenAPI.Components.Schemas
+	explicit := p.explicitSchemaNames()
+	if strategy == SchemaNamingFull && len(explicit) == 0 {
+		return nil
+	}
+
+	// RegisterType stores the known schemas under their type name too, those copies are dropped
+	typed := map[*SchemaObject]bool{}
+	for id := range p.KnownIDType {
+		if schema, ok := schemas[id]; ok {
+			typed[schema] = true
+		}
+	}
+	for key, schema := range schemas {
+		if _, ok := p.KnownIDType[key]; !ok && typed[schema] {
+			delete(schemas, key)
+		}
+	}
+
+	ids := map[string][]string{}
+	for id, goType := range p.KnownIDType {
+		if _, ok := schemas[id]; !ok {
+			continue
+		}
+		name, ok := explicit[id]
+		if !ok {
+			if name, err = schemaName(id, goType); err != nil {
+				return err
+			}
+		}
+		ids[name] = append(ids[name], id)
+	}
+	names := make([]string, 0, len(ids))
+	for name := range ids {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	renames := map[string]string{}
+	used := map[string]bool{}
+	for key := range schemas {
+		if _, ok := p.KnownIDType[key]; !ok {
+			used[key] = true
+		}
+	}
+	var collisions, renamed []string
+	for _, name := range names {
+		sort.Strings(ids[name])
+		if len(ids[name]) == 1 && !used[name] {
+			renames[ids[name][0]] = name
+			used[name] = true
+			continue
+		}
+		collisions = append(collisions, p.describeCollision(name, ids[name]))
+		var named []string
+		for _, id := range ids[name] {
+			if explicit[id] == name && !used[name] {
+				// a name given explicitly is kept, the other go types are renamed
+				renames[id] = name
+				named = append(named, id)
+			}
+		}
+		if len(named) > 1 {
+			return fmt.Errorf("schema name collisions: %s", p.describeCollision(name, named))
+		}
+		for _, id := range named {
+			used[renames[id]] = true
+		}
+		for _, id := range ids[name] {
+			if _, ok := renames[id]; ok {
+				continue
+			}
+			renames[id] = p.uniqueName(id, ids[name], used)
+			used[renames[id]] = true
+			renamed = append(renamed, id)
+		}
+	}
+	if len(collisions) != 0 && (mode == SchemaCollisionFail || p.RunInStrictMode) {
+		return fmt.Errorf("schema name collisions: %s", strings.Join(collisions, "; "))
+	}
+	for _, id := range renamed {
+		log.Warnf("Schema name %s is shared, %s is named %s", p.KnownIDType[id].Name, describeType(p.KnownIDType[id]), renames[id])
+	}
+	for id, name := range renames {
+		if id == name {
+			delete(renames, id)
+		}
+	}
+	p.OpenAPI.RenameSchemas(renames)
+	p.renameParamTypes(renames)
+	return nil
+}
+
+// renameParamTypes renames the type of the params of a model type, which the operation parser sets to
+// the schema id along with the reference
+func (p *parser) renameParamTypes(renames map[string]string) {
+	rename := func(schema *SchemaObject) {
+		if schema == nil {
+			return
+		}
+		if name, ok := renames[schema.Type]; ok && schema.Ref == SchemaRefPrefix+name {
+			schema.Type = name
+		}
+	}
+	for _, parameter := range p.OpenAPI.Components.Parameters {
+		if parameter != nil {
+			rename(parameter.Schema)
+		}
+	}
+	for _, item := range p.OpenAPI.Paths {
+		for _, method := range Methods {
+			if op := item.Operation(method); op != nil {
+				for i := range op.Parameters {
+					rename(op.Parameters[i].Schema)
+				}
+			}
+		}
+	}
+}
+
+// schemaNamer returns the function naming the schema of a go type with a naming strategy
+func schemaNamer(strategy string) (func(id string, goType *model.Type) (string, error), error) {
+	switch strategy {
+	case SchemaNamingFull:
+		return func(id string, goType *model.Type) (string, error) {
+			return id, nil
+		}, nil
+	case SchemaNamingPkg:
+		return func(id string, goType *model.Type) (string, error) {
+			data := newSchemaNameData(goType)
+			return data.Pkg + "." + data.Type, nil
+		}, nil
+	case SchemaNamingType:
+		return func(id string, goType *model.Type) (string, error) {
+			return newSchemaNameData(goType).Type, nil
+		}, nil
+	}
+	if !strings.Contains(strategy, "{{") {
+		return nil, fmt.Errorf("unknown schema naming %s, expected %s, %s, %s or a template", strategy, SchemaNamingFull, SchemaNamingPkg, SchemaNamingType)
+	}
+	nameTemplate, err := template.New("schema-naming").Funcs(schemaNameFuncs).Parse(strategy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema naming template %s: %v", strategy, err)
+	}
+	return func(id string, goType *model.Type) (string, error) {
+		var name bytes.Buffer
+		if err := nameTemplate.Execute(&name, newSchemaNameData(goType)); err != nil {
+			return "", fmt.Errorf("schema naming template of %s: %v", id, err)
+		}
+		if name.Len() == 0 {
+			return "", fmt.Errorf("schema naming template %s gives an empty name to %s", strategy, id)
+		}
+		return name.String(), nil
+	}, nil
+}
+
+func newSchemaNameData(goType *model.Type) schemaNameData {
+	pkgPath := utils.ReplaceBackslash(goType.PkgName)
+	return schemaNameData{
+		Pkg:     pkgPath[strings.LastIndex(pkgPath, "/")+1:],
+		PkgPath: pkgPath,
+		Type:    utils.GenSchemaObjectID(goType.PkgName, goType.Name, true),
+	}
+}
+
+// explicitSchemaNames returns the names given by the SchemaNames and the @SchemaName annotations, by schema id
+func (p *parser) explicitSchemaNames() map[string]string {
+	names := map[string]string{}
+	matched := map[string]bool{}
+	for id, goType := range p.KnownIDType {
+		if goType.SchemaName != "" {
+			names[id] = goType.SchemaName
+		}
+		data := newSchemaNameData(goType)
+		for _, key := range []string{data.PkgPath + "." + data.Type, data.Pkg + "." + data.Type, id} {
+			if name, ok := p.Flags.SchemaNames[key]; ok {
+				names[id] = name
+				matched[key] = true
+				break
+			}
+		}
+	}
+	for id, goType := range p.KnownIDType {
+		if _, ok := names[id]; !ok && goType.Of != "" && names[goType.Of] != "" {
+			names[id] = names[goType.Of] + InputSchemaSuffix
+		}
+	}
+	for key := range p.Flags.SchemaNames {
+		if !matched[key] {
+			log.Warnf("Schema name of %s is given but no schema is parsed from it", key)
+		}
+	}
+	return names
+}
+
+// uniqueName returns the name of a go type prefixed with the shortest suffix of its package no other
+// go type of the same name has
+func (p *parser) uniqueName(id string, ids []string, used map[string]bool) string {
+	goType := p.KnownIDType[id]
+	segments := strings.Split(utils.ReplaceBackslash(goType.PkgName), "/")
+	name := utils.GenSchemaObjectID(goType.PkgName, goType.Name, true)
+	for n := 1; n <= len(segments); n++ {
+		suffix := strings.Join(segments[len(segments)-n:], "/")
+		unique := true
+		for _, other := range ids {
+			otherPkg := utils.ReplaceBackslash(p.KnownIDType[other].PkgName)
+			if other != id && (otherPkg == suffix || strings.HasSuffix(otherPkg, "/"+suffix)) {
+				unique = false
+				break
+			}
+		}
+		candidate := strings.Join(append(segments[len(segments)-n:], name), ".")
+		if unique && !used[candidate] {
+			return candidate
+		}
+	}
+	return id
+}
+
+func (p *parser) describeCollision(name string, ids []string) string {
+	var types []string
+	for _, id := range ids {
+		types = append(types, describeType(p.KnownIDType[id]))
+	}
+	if len(ids) == 1 {
+		types = append(types, "another component")
+	}
+	return fmt.Sprintf("%s is the name of %s", name, strings.Join(types, " and "))
+}
+
+func describeType(goType *model.Type) string {
+	description := utils.ReplaceBackslash(goType.PkgName) + "." + goType.Name
+	if goType.Position.IsValid() {
+		description += fmt.Sprintf(" (%s)", goType.Position)
+	}
+	return description
+}
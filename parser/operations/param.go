@@ -27,7 +27,8 @@ func (p *parser) parseParamComment(pkgPath, pkgName string, operation *oas.Opera
 	appendDescription(&parameterObject, matches[5])
 	appendExample(&parameterObject, matches[7]) // 6 group is using for checking if example exist
 
-	goType := getType(re, matches)
+	goType := p.scopedType(pkgName, getType(re, matches))
+	matches[3] = goType
 
 	// `file`, `form`
 	appendRequestBody(operation, parameterObject, goType)
@@ -47,7 +48,7 @@ func (p *parser) parseRequestBody(pkgPath string, pkgName string, operation *oas
 			Required: parameterObject.Required,
 		}
 	}
-	if strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[]") || goType == "time.Time" {
+	if strings.HasPrefix(goType, "[]") || utils.IsMapType(goType) || goType == "time.Time" {
 		return p.parseArrayMapOrTimeType(pkgPath, pkgName, operation, goType)
 	}
 	return p.parseGoBasicTypeOrStructType(pkgPath, pkgName, operation, matches)
@@ -85,6 +86,11 @@ func (p *parser) appendQueryParam(pkgPath string, pkgName string, operation *oas
 	if goType == "time.Time" {
 		return p.appendTimeParam(pkgPath, pkgName, operation, parameterObject, goType)
 	}
+	if mapping, _, _, ok := p.TypeMapping(pkgName, goType); ok {
+		parameterObject.Schema = mapping.Schema()
+		operation.Parameters = append(operation.Parameters, parameterObject)
+		return nil
+	}
 	if utils.IsGoTypeOASType(goType) {
 		p.appendGoTypeParams(parameterObject, goType, operation)
 	}
@@ -167,9 +173,7 @@ func appendRequestBody(operation *oas.OperationObject, parameterObject oas.Param
 }
 
 func getType(re *regexp.Regexp, matches []string) string {
-	re = regexp.MustCompile(`\[\w*\]`)
-	goType := re.ReplaceAllString(matches[3], "[]")
-	return goType
+	return utils.ReplaceArrayLengths(matches[3])
 }
 
 func appendRequired(paramObject *oas.ParameterObject, isRequired string) {
@@ -8,10 +8,11 @@ import (
 	"github.com/parvez3019/go-swagger3/openApi3Schema"
 	"github.com/parvez3019/go-swagger3/parser/model"
 	"github.com/parvez3019/go-swagger3/parser/schema"
+	"github.com/parvez3019/go-swagger3/parser/utils"
 )
 
 type Parser interface {
-	Parse(pkgPath, pkgName string, astComments []*ast.Comment) error
+	Parse(pkgPath, pkgName, scope string, astComments []*ast.Comment) error
 }
 
 type parser struct {
@@ -20,6 +21,7 @@ type parser struct {
 	model.Utils
 	schema.Parser
 	usedOperationIds map[string]struct{} // Track used operation IDs
+	scope            string              // Scope of the types declared in the handler
 }
 
 func NewParser(utils model.Utils, api *openApi3Schema.OpenAPIObject, schemaParser schema.Parser) Parser {
@@ -31,11 +33,14 @@ func NewParser(utils model.Utils, api *openApi3Schema.OpenAPIObject, schemaParse
 	}
 }
 
-func (p *parser) Parse(pkgPath, pkgName string, astComments []*ast.Comment) error {
+// Parse parses the operation documented by the comments of a handler. scope is the one of the types
+// declared in the handler, which its comments can refer to by their name
+func (p *parser) Parse(pkgPath, pkgName, scope string, astComments []*ast.Comment) error {
 	operation := &openApi3Schema.OperationObject{Responses: map[string]*openApi3Schema.ResponseObject{}}
 	if !strings.HasPrefix(pkgPath, p.ModulePath) || (p.HandlerPath != "" && !strings.HasPrefix(pkgPath, p.HandlerPath)) {
 		return nil
 	}
+	p.scope = scope
 
 	for _, astComment := range astComments {
 		comment := strings.TrimSpace(strings.TrimLeft(astComment.Text, "/"))
@@ -88,3 +93,26 @@ func (p *parser) parseOperationFromComment(pkgPath string, pkgName string, comme
 	}
 	return nil
 }
+
+// scopedType returns the name a type declared in the handler is known by, eg. GetUser@Response for
+// Response, keeping its [] and map[] prefixes
+func (p *parser) scopedType(pkgName, goType string) string {
+	if p.scope == "" {
+		return goType
+	}
+	name := goType
+	for {
+		if strings.HasPrefix(name, "[]") {
+			name = name[len("[]"):]
+		} else if _, valueType, ok := utils.SplitMapType(name); ok {
+			name = valueType
+		} else {
+			break
+		}
+	}
+	scopedName := strings.Join([]string{p.scope, name}, "@")
+	if _, ok := p.TypeSpecs[pkgName][scopedName]; !ok {
+		return goType
+	}
+	return strings.TrimSuffix(goType, name) + scopedName
+}
@@ -41,7 +41,7 @@ func (p *parser) parseResponseComment(pkgPath, pkgName string, operation *oas.Op
 	switch matches[2] {
 
 	case "object", "array", "{object}", "{array}":
-		err = p.complexResponseObject(pkgPath, pkgName, matches[3], responseObject)
+		err = p.complexResponseObject(pkgPath, pkgName, p.scopedType(pkgName, matches[3]), responseObject)
 	case "{string}", "{integer}", "{boolean}", "string", "integer", "boolean":
 		err = p.simpleResponseObject(matches[2], responseObject)
 	case "":
@@ -61,9 +61,8 @@ func (p *parser) parseResponseComment(pkgPath, pkgName string, operation *oas.Op
 // function to parse cases of jsonType in case "object", "array", "{object}", "{array}":
 func (p *parser) complexResponseObject(pkgPath, pkgName, typ string, responseObject *oas.ResponseObject) error {
 
-	re := regexp.MustCompile(`\[\w*\]`)
-	goType := re.ReplaceAllString(typ, "[]")
-	if strings.HasPrefix(goType, "map[]") {
+	goType := utils.ReplaceArrayLengths(typ)
+	if utils.IsMapType(strings.TrimLeft(goType, "[]")) {
 		schema, err := p.ParseSchemaObject(pkgPath, pkgName, goType)
 		if err != nil {
 			p.Debug("parseResponseComment cannot parse goType", goType)
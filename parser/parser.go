@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"fmt"
 	"github.com/parvez3019/go-swagger3/logger"
 	. "github.com/parvez3019/go-swagger3/openApi3Schema"
 	"github.com/parvez3019/go-swagger3/parser/apis"
@@ -11,6 +12,7 @@ import (
 	"github.com/parvez3019/go-swagger3/parser/schema"
 	log "github.com/sirupsen/logrus"
 	"go/ast"
+	"go/token"
 )
 
 type parser struct {
@@ -25,6 +27,10 @@ type parser struct {
 	model.Utils
 }
 
+// Parser is the type NewParser returns, named for the callers setting its options apart from the
+// call, eg. in a function
+type Parser = parser
+
 func NewParser(modulePath, mainFilePath, handlerPath string, debug, strict, schemaWithoutPkg bool) *parser {
 	return &parser{
 		Utils: model.Utils{
@@ -36,12 +42,62 @@ func NewParser(modulePath, mainFilePath, handlerPath string, debug, strict, sche
 	}
 }
 
+// TypeMappingFile sets a yaml or json file mapping go types to the schema they are documented with,
+// completing model.DefaultTypeMappings
+func (p *parser) TypeMappingFile(path string) *parser {
+	p.Flags.TypeMappingFile = path
+	return p
+}
+
+// HoistInlineStructs makes components of the anonymous structs of the fields, eg. OrderItems for the
+// Items []struct{...} field of Order, instead of inline schemas
+func (p *parser) HoistInlineStructs(hoist bool) *parser {
+	p.Flags.HoistInlineStructs = hoist
+	return p
+}
+
+// JSONNaming sets how the struct fields are named for encoders other than encoding/json:
+// schema.JSONNamingStd (default), schema.JSONNamingCamel, schema.JSONNamingSnake or
+// schema.JSONNamingProtojson
+func (p *parser) JSONNaming(naming string) *parser {
+	p.Flags.JSONNaming = naming
+	return p
+}
+
+// RequiredPolicy sets the rules inferring the required properties besides the explicit ones, eg.
+// validate,response:non-pointer, see model.ParseRequiredPolicy
+func (p *parser) RequiredPolicy(policy string) *parser {
+	p.Flags.RequiredPolicy = policy
+	return p
+}
+
+// DirectionVariants makes the schemas differing between the request bodies and the responses two
+// schemas, eg. UserInput without the readOnly properties of User and User without its writeOnly ones
+func (p *parser) DirectionVariants(split bool) *parser {
+	p.Flags.DirectionVariants = split
+	return p
+}
+
 func (p *parser) Init() (*parser, error) {
 	p.Logger = logger.SetDebugMode(p.RunInDebugMode)
 
 	if err := p.verifyAndSetPaths(); err != nil {
 		return nil, err
 	}
+	typeMappings, err := model.LoadTypeMappings(p.Flags.TypeMappingFile)
+	if err != nil {
+		return nil, err
+	}
+	p.TypeMappings = typeMappings
+	requiredRules, err := model.ParseRequiredPolicy(p.Flags.RequiredPolicy)
+	if err != nil {
+		return nil, err
+	}
+	p.RequiredRules = requiredRules
+	if !schema.IsJSONNaming(p.Flags.JSONNaming) {
+		return nil, fmt.Errorf("unknown json naming %s, expected %s, %s, %s or %s", p.Flags.JSONNaming,
+			schema.JSONNamingStd, schema.JSONNamingCamel, schema.JSONNamingSnake, schema.JSONNamingProtojson)
+	}
 
 	p.schemaParser = schema.NewParser(p.Utils, p.OpenAPI)
 	p.apiParser = apis.NewParser(p.Utils, p.OpenAPI, p.schemaParser)
@@ -74,6 +130,16 @@ func (p *parser) Parse() (OpenAPIObject, error) {
 		return OpenAPIObject{}, err
 	}
 
+	if p.Flags.DirectionVariants {
+		p.splitDirections()
+	}
+	p.applyRequiredPolicy()
+
+	err = p.nameSchemas()
+	if err != nil {
+		return OpenAPIObject{}, err
+	}
+
 	log.Info("Parsing Completed ...")
 	return *p.OpenAPI, nil
 }
@@ -113,6 +179,8 @@ func initPkgAndSpecs() *model.PkgAndSpecs {
 		KnownNamePkg:            make(map[string]*model.Pkg, 0),
 		KnownPathPkg:            make(map[string]*model.Pkg, 0),
 		KnownIDSchema:           make(map[string]*SchemaObject, 0),
+		KnownIDType:             make(map[string]*model.Type, 0),
+		FileSet:                 token.NewFileSet(),
 		TypeSpecs:               make(map[string]map[string]*ast.TypeSpec, 0),
 		PkgPathAstPkgCache:      make(map[string]map[string]*ast.Package, 0),
 		PkgNameImportedPkgAlias: make(map[string]map[string][]string, 0),
@@ -0,0 +1,82 @@
+package parser
+
+import (
+	. "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/parser/utils"
+)
+
+// applyRequiredPolicy makes the properties required in one direction only by the required policy
+// required in the schemas reached by the request bodies only or by the responses only. The schemas
+// reached by both keep the properties required in both directions.
+func (p *parser) applyRequiredPolicy() {
+	requests, responses := p.reachedSchemas()
+	all := map[*SchemaObject]bool{}
+	for _, schema := range p.OpenAPI.Components.Schemas {
+		p.OpenAPI.ReachSchemas(schema, all)
+	}
+	for schema := range requests {
+		all[schema] = true
+	}
+	for schema := range responses {
+		all[schema] = true
+	}
+	for schema := range all {
+		if len(schema.RequestRequired) == 0 && len(schema.ResponseRequired) == 0 {
+			continue
+		}
+		switch {
+		case requests[schema] && !responses[schema]:
+			setRequired(schema, schema.RequestRequired)
+		case responses[schema] && !requests[schema]:
+			setRequired(schema, schema.ResponseRequired)
+		case requests[schema]:
+			p.Debugf("%s is used by request bodies and responses, its properties required in one direction only are optional", schema.ID)
+		}
+	}
+}
+
+// reachedSchemas returns the schemas the request bodies and the parameters reach, and the ones the
+// responses reach
+func (p *parser) reachedSchemas() (map[*SchemaObject]bool, map[*SchemaObject]bool) {
+	requests, responses := map[*SchemaObject]bool{}, map[*SchemaObject]bool{}
+	for _, item := range p.OpenAPI.Paths {
+		for _, method := range Methods {
+			op := item.Operation(method)
+			if op == nil {
+				continue
+			}
+			for i := range op.Parameters {
+				p.OpenAPI.ReachSchemas(op.Parameters[i].Schema, requests)
+			}
+			if op.RequestBody != nil {
+				for _, mediaType := range op.RequestBody.Content {
+					if mediaType != nil {
+						p.OpenAPI.ReachSchemas(&mediaType.Schema, requests)
+					}
+				}
+			}
+			for _, response := range op.Responses {
+				if response == nil {
+					continue
+				}
+				for _, mediaType := range response.Content {
+					if mediaType != nil {
+						p.OpenAPI.ReachSchemas(&mediaType.Schema, responses)
+					}
+				}
+			}
+		}
+	}
+	return requests, responses
+}
+
+// setRequired adds required properties to a schema, keeping the order of the properties
+func setRequired(schema *SchemaObject, names []string) {
+	required := make([]string, 0, len(schema.Required)+len(names))
+	for _, name := range schema.Properties.Keys() {
+		if utils.IsInStringList(schema.Required, name) || utils.IsInStringList(names, name) {
+			required = append(required, name)
+		}
+	}
+	schema.Required = required
+}
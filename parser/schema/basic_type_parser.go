@@ -1,7 +1,6 @@
 package schema
 
 import (
-	"github.com/iancoleman/orderedmap"
 	. "github.com/parvez3019/go-swagger3/openApi3Schema"
 	"github.com/parvez3019/go-swagger3/parser/utils"
 	"strings"
@@ -13,7 +12,7 @@ func (p *parser) parseBasicTypeSchemaObject(pkgPath string, pkgName string, type
 	// handler basic and some specific typeName
 	if strings.HasPrefix(typeName, "[]") {
 		return p.parseArrayType(pkgPath, pkgName, typeName, schemaObject, err)
-	} else if strings.HasPrefix(typeName, "map[]") {
+	} else if utils.IsMapType(typeName) {
 		return p.parseMapType(pkgPath, pkgName, typeName, schemaObject)
 	} else if typeName == "time.Time" {
 		return p.parseTimeType(schemaObject)
@@ -43,7 +42,11 @@ func (p *parser) parseTimeType(schemaObject SchemaObject) (*SchemaObject, error,
 func (p *parser) parseArrayType(pkgPath string, pkgName string, typeName string, schemaObject SchemaObject, err error) (*SchemaObject, error, bool) {
 	schemaObject.Type = "array"
 	itemTypeName := typeName[2:]
-	schema, ok := p.KnownIDSchema[utils.GenSchemaObjectID(pkgName, itemTypeName, p.SchemaWithoutPkg)]
+	if schemaObjectID, ok := p.buildingSchemaObjectID(pkgPath, pkgName, itemTypeName); ok {
+		schemaObject.Items = &SchemaObject{Ref: utils.AddSchemaRefLinkPrefix(schemaObjectID)}
+		return &schemaObject, nil, true
+	}
+	schema, ok := p.KnownIDSchema[utils.GenSchemaObjectID(pkgName, itemTypeName, false)]
 	if ok {
 		schemaObject.Items = &SchemaObject{Ref: utils.AddSchemaRefLinkPrefix(schema.ID)}
 		return &schemaObject, nil, true
@@ -55,19 +58,42 @@ func (p *parser) parseArrayType(pkgPath string, pkgName string, typeName string,
 	return &schemaObject, nil, true
 }
 
+// parseMapType returns an object whose additional properties are the values of the map. The keys other
+// than strings are flagged with their go type.
 func (p *parser) parseMapType(pkgPath string, pkgName string, typeName string, schemaObject SchemaObject) (*SchemaObject, error, bool) {
 	schemaObject.Type = "object"
-	itemTypeName := typeName[5:]
-	schema, ok := p.KnownIDSchema[utils.GenSchemaObjectID(pkgName, itemTypeName, p.SchemaWithoutPkg)]
-	if ok {
-		schemaObject.Items = &SchemaObject{Ref: utils.AddSchemaRefLinkPrefix(schema.ID)}
-		return &schemaObject, nil, true
+	keyTypeName, valueTypeName, _ := utils.SplitMapType(typeName)
+	if keyTypeName != "" && keyTypeName != "string" {
+		schemaObject.KeyType = keyTypeName
 	}
-	schemaProperty, err := p.ParseSchemaObject(pkgPath, pkgName, itemTypeName)
+	valueSchema, err := p.parseValueSchemaObject(pkgPath, pkgName, valueTypeName)
 	if err != nil {
 		return nil, err, true
 	}
-	schemaObject.Properties = orderedmap.New()
-	schemaObject.Properties.Set("key", schemaProperty)
+	schemaObject.AdditionalProperties = AdditionalPropertiesOf(valueSchema)
 	return &schemaObject, nil, true
 }
+
+// parseValueSchemaObject returns the schema of the values of a map, a reference for the custom types
+func (p *parser) parseValueSchemaObject(pkgPath string, pkgName string, typeName string) (*SchemaObject, error) {
+	if strings.HasPrefix(typeName, "[]") {
+		items, err := p.parseValueSchemaObject(pkgPath, pkgName, typeName[len("[]"):])
+		if err != nil {
+			return nil, err
+		}
+		return &SchemaObject{Type: "array", Items: items}, nil
+	}
+	if _, _, _, ok := p.TypeMapping(pkgName, typeName); ok || utils.IsMapType(typeName) || typeName == "time.Time" ||
+		utils.IsGoTypeOASType(typeName) {
+		return p.ParseSchemaObject(pkgPath, pkgName, typeName)
+	}
+	if utils.IsBasicGoType(typeName) || utils.IsInterfaceType(typeName) {
+		// any value
+		return &SchemaObject{}, nil
+	}
+	schemaObjectID, err := p.RegisterType(pkgPath, pkgName, typeName)
+	if err != nil {
+		return nil, err
+	}
+	return &SchemaObject{Ref: utils.AddSchemaRefLinkPrefix(schemaObjectID)}, nil
+}
@@ -1,7 +1,6 @@
 package schema
 
 import (
-	"encoding/json"
 	"fmt"
 	"go/ast"
 	"reflect"
@@ -10,74 +9,52 @@ import (
 
 	"github.com/iancoleman/orderedmap"
 	. "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/parser/model"
 	"github.com/parvez3019/go-swagger3/parser/utils"
 	log "github.com/sirupsen/logrus"
 )
 
 func (p *parser) parseCustomTypeSchemaObject(pkgPath string, pkgName string, typeName string) (*SchemaObject, error) {
-	var typeSpec *ast.TypeSpec
-	var exist bool
 	var schemaObject SchemaObject
 
 	// handler other type
 	typeNameParts := strings.Split(typeName, ".")
-	if len(typeNameParts) == 1 {
-		typeSpec, exist = p.getTypeSpec(pkgName, typeName)
-		if !exist {
+	typeSpec, pkgPath, pkgName, exist := p.lookupTypeSpec(pkgPath, pkgName, typeName)
+	if !exist {
+		if len(typeNameParts) == 1 {
 			log.Fatalf("Can not find definition of %s ast.TypeSpec. Current package %s", typeName, pkgName)
 		}
-		schemaObject.PkgName = pkgName
-		schemaObject.ID = utils.GenSchemaObjectID(pkgName, typeName, p.SchemaWithoutPkg)
-		p.KnownIDSchema[schemaObject.ID] = &schemaObject
-	} else {
-		guessPkgName := strings.Join(typeNameParts[:len(typeNameParts)-1], "/")
-		guessPkgPath := ""
-		for i := range p.KnownPkgs {
-			if guessPkgName == p.KnownPkgs[i].Name {
-				guessPkgPath = p.KnownPkgs[i].Path
-				break
-			}
-		}
-		guessTypeName := typeNameParts[len(typeNameParts)-1]
-		typeSpec, exist = p.getTypeSpec(guessPkgName, guessTypeName)
-		if !exist {
-			found := false
-			aliases := p.PkgNameImportedPkgAlias[pkgName][guessPkgName]
-			for k := range p.PkgNameImportedPkgAlias[pkgName] {
-				if k == guessPkgName && len(aliases) != 0 {
-					found = true
-					break
-				}
-			}
-			if !found {
-				p.Debugf("unknown guess %s ast.TypeSpec in package %s", guessTypeName, guessPkgName)
-				return &schemaObject, nil
-			}
-			for index, currentAliasName := range aliases {
-				guessPkgName = currentAliasName
-				guessPkgPath = ""
-				for i := range p.KnownPkgs {
-					if guessPkgName == p.KnownPkgs[i].Name {
-						guessPkgPath = p.KnownPkgs[i].Path
-						break
-					}
-				}
-				// p.debugf("guess %s ast.TypeSpec in package %s", guessTypeName, guessPkgName)
-				typeSpec, exist = p.getTypeSpec(guessPkgName, guessTypeName)
-				if exist {
-					break
-				}
-				if !exist && index == len(aliases)-1 {
-					p.Debugf("can not find definition of guess %s ast.TypeSpec in package %s", guessTypeName, guessPkgName)
-					return &schemaObject, nil
-				}
-			}
+		return &schemaObject, nil
+	}
+	schemaObject.PkgName = pkgName
+	schemaObject.ID = utils.GenSchemaObjectID(pkgName, typeName, false)
+	if placeholder, ok := p.building[schemaObject.ID]; ok {
+		// a back edge reaching the type under another name, the first parse completes the schema
+		return placeholder, nil
+	}
+	p.beginSchemaObject(&schemaObject)
+	defer p.endSchemaObject(&schemaObject)
+	p.registerType(schemaObject.ID, pkgName, typeNameParts[len(typeNameParts)-1], typeSpec)
 
-			schemaObject.PkgName = guessPkgName
-			schemaObject.ID = utils.GenSchemaObjectID(guessPkgName, guessTypeName, p.SchemaWithoutPkg)
-			p.KnownIDSchema[schemaObject.ID] = &schemaObject
+	marshalerSchemaObject, err := p.parseMarshalerSchemaObject(pkgPath, pkgName, typeNameParts[len(typeNameParts)-1], typeSpec)
+	if err != nil {
+		return nil, err
+	}
+	if marshalerSchemaObject != nil {
+		marshalerSchemaObject.ID, marshalerSchemaObject.PkgName = schemaObject.ID, schemaObject.PkgName
+		schemaObject = *marshalerSchemaObject
+		return &schemaObject, nil
+	}
+	if _, ok := typeSpec.Type.(*ast.StructType); !ok {
+		// type Lines []struct{...}
+		if inlineSchemaObject, ok, err := p.parseInlineStructSchemaObject(pkgPath, pkgName, nil, "", typeSpec.Type); ok {
+			if err != nil {
+				return nil, err
+			}
+			inlineSchemaObject.ID, inlineSchemaObject.PkgName = schemaObject.ID, schemaObject.PkgName
+			schemaObject = *inlineSchemaObject
+			return &schemaObject, nil
 		}
-		pkgPath, pkgName = guessPkgPath, guessPkgName
 	}
 
 	if astIdent, ok := typeSpec.Type.(*ast.Ident); ok {
@@ -87,7 +64,9 @@ func (p *parser) parseCustomTypeSchemaObject(pkgPath string, pkgName string, typ
 	} else if astStructType, ok := typeSpec.Type.(*ast.StructType); ok {
 		schemaObject.Type = "object"
 		if astStructType.Fields != nil {
-			p.parseSchemaPropertiesFromStructFields(pkgPath, pkgName, &schemaObject, astStructType.Fields.List)
+			if err := p.parseSchemaPropertiesFromStructFields(pkgPath, pkgName, &schemaObject, astStructType.Fields.List); err != nil {
+				return nil, err
+			}
 		}
 		typeNameParts := strings.Split(typeName, ".")
 		if len(typeNameParts) > 1 {
@@ -95,7 +74,9 @@ func (p *parser) parseCustomTypeSchemaObject(pkgPath string, pkgName string, typ
 		}
 		if !utils.IsBasicGoType(typeName) {
 			_, err := p.RegisterType(pkgPath, pkgName, typeName)
-			if err != nil {
+			if isTagError(err) {
+				return nil, err
+			} else if err != nil {
 				p.Debugf("ParseSchemaObject parse array items err: %s", err.Error())
 			}
 		}
@@ -106,7 +87,9 @@ func (p *parser) parseCustomTypeSchemaObject(pkgPath string, pkgName string, typ
 		typeAsString = strings.TrimLeft(typeAsString, "*")
 		if !utils.IsBasicGoType(typeAsString) {
 			schemaItemsSchemeaObjectID, err := p.RegisterType(pkgPath, pkgName, typeAsString)
-			if err != nil {
+			if isTagError(err) {
+				return nil, err
+			} else if err != nil {
 				p.Debugf("ParseSchemaObject parse array items err: %s", err.Error())
 			} else {
 				schemaObject.Items.Ref = utils.AddSchemaRefLinkPrefix(schemaItemsSchemeaObjectID)
@@ -115,26 +98,76 @@ func (p *parser) parseCustomTypeSchemaObject(pkgPath string, pkgName string, typ
 			schemaObject.Items.Type = utils.GoTypesOASTypes[typeAsString]
 		}
 	} else if astMapType, ok := typeSpec.Type.(*ast.MapType); ok {
-		schemaObject.Type = "object"
-		schemaObject.Properties = orderedmap.New()
-		propertySchema := &SchemaObject{}
-		schemaObject.Properties.Set("key", propertySchema)
-		typeAsString := p.getTypeAsString(astMapType.Value)
-		typeAsString = strings.TrimLeft(typeAsString, "*")
-		if !utils.IsBasicGoType(typeAsString) {
-			schemaItemsSchemeaObjectID, err := p.RegisterType(pkgPath, pkgName, typeAsString)
-			if err != nil {
-				p.Debugf("ParseSchemaObject parse array items err: %s", err.Error())
-			} else {
-				propertySchema.Ref = utils.AddSchemaRefLinkPrefix(schemaItemsSchemeaObjectID)
-			}
-		} else if utils.IsGoTypeOASType(typeAsString) {
-			propertySchema.Type = utils.GoTypesOASTypes[typeAsString]
+		mapSchemaObject, err, _ := p.parseMapType(pkgPath, pkgName, p.getTypeAsString(astMapType), schemaObject)
+		if err != nil {
+			p.Debugf("ParseSchemaObject parse map values err: %s", err.Error())
+		} else {
+			schemaObject = *mapSchemaObject
 		}
 	}
 	return &schemaObject, nil
 }
 
+// registerType records the go type, the source location and the @SchemaName of a schema id
+func (p *parser) registerType(id, pkgName, typeName string, typeSpec *ast.TypeSpec) {
+	if p.KnownIDType == nil {
+		return
+	}
+	goType := &model.Type{PkgName: pkgName, Name: typeName}
+	if p.FileSet != nil && typeSpec != nil {
+		goType.Position = p.FileSet.Position(typeSpec.Pos())
+	}
+	if typeSpec != nil && typeSpec.Doc != nil {
+		for _, comment := range typeSpec.Doc.List {
+			fields := strings.Fields(strings.TrimPrefix(comment.Text, "//"))
+			if len(fields) > 1 && strings.ToLower(fields[0]) == "@schemaname" {
+				goType.SchemaName = fields[1]
+			}
+		}
+	}
+	p.KnownIDType[id] = goType
+}
+
+// lookupTypeSpec returns the type spec of a type name, qualified or not, with the path and the name of
+// its package
+func (p *parser) lookupTypeSpec(pkgPath, pkgName, typeName string) (*ast.TypeSpec, string, string, bool) {
+	typeNameParts := strings.Split(typeName, ".")
+	if len(typeNameParts) == 1 {
+		typeSpec, exist := p.getTypeSpec(pkgName, typeName)
+		return typeSpec, pkgPath, pkgName, exist
+	}
+	guessPkgName := strings.Join(typeNameParts[:len(typeNameParts)-1], "/")
+	guessTypeName := typeNameParts[len(typeNameParts)-1]
+	typeSpec, exist := p.getTypeSpec(guessPkgName, guessTypeName)
+	if exist {
+		return typeSpec, p.getPkgPath(guessPkgName), guessPkgName, true
+	}
+	aliases := p.PkgNameImportedPkgAlias[pkgName][guessPkgName]
+	if len(aliases) == 0 {
+		p.Debugf("unknown guess %s ast.TypeSpec in package %s", guessTypeName, guessPkgName)
+		return nil, pkgPath, pkgName, false
+	}
+	for _, currentAliasName := range aliases {
+		// p.debugf("guess %s ast.TypeSpec in package %s", guessTypeName, guessPkgName)
+		typeSpec, exist = p.getTypeSpec(currentAliasName, guessTypeName)
+		if exist {
+			return typeSpec, p.getPkgPath(currentAliasName), currentAliasName, true
+		}
+	}
+	p.Debugf("can not find definition of guess %s ast.TypeSpec in package %s", guessTypeName, aliases[len(aliases)-1])
+	return nil, pkgPath, pkgName, false
+}
+
+// getPkgPath returns the path of a known package, empty when it is unknown
+func (p *parser) getPkgPath(pkgName string) string {
+	for i := range p.KnownPkgs {
+		if pkgName == p.KnownPkgs[i].Name {
+			return p.KnownPkgs[i].Path
+		}
+	}
+	return ""
+}
+
 func (p *parser) getTypeSpec(pkgName, typeName string) (*ast.TypeSpec, bool) {
 	pkgTypeSpecs, exist := p.TypeSpecs[pkgName]
 	if !exist {
@@ -147,230 +180,215 @@ func (p *parser) getTypeSpec(pkgName, typeName string) (*ast.TypeSpec, bool) {
 	return astTypeSpec, true
 }
 
-func (p *parser) parseSchemaPropertiesFromStructFields(pkgPath, pkgName string, structSchema *SchemaObject, astFields []*ast.Field) {
+func (p *parser) parseSchemaPropertiesFromStructFields(pkgPath, pkgName string, structSchema *SchemaObject, astFields []*ast.Field) error {
 	if astFields == nil {
-		return
+		return nil
 	}
-	var err error
 	structSchema.Properties = orderedmap.New()
+	structSchema.StructFields = map[string]StructField{}
 	if structSchema.DisabledFieldNames == nil {
 		structSchema.DisabledFieldNames = map[string]struct{}{}
 	}
-astFieldsLoop:
 	for _, astField := range astFields {
-		if len(astField.Names) == 0 {
-			continue
-		}
-
-		if astField.Tag != nil {
-			tag := reflect.StructTag(strings.Trim(astField.Tag.Value, "`"))
-			if tag.Get("skip") == "true" {
-				// If the field has a 'skip:"true"' tag, skip this iteration
-				continue
+		// the fields hidden with "-" hide the fields of the same go name of the embedded structs
+		for _, astIdent := range astField.Names {
+			if isOmittedField(getFieldTag(astField)) {
+				structSchema.DisabledFieldNames[astIdent.Name] = struct{}{}
 			}
 		}
-
-		fieldSchema := &SchemaObject{}
-		typeAsString := p.getTypeAsString(astField.Type)
-		typeAsString = strings.TrimLeft(typeAsString, "*")
-		if strings.HasPrefix(typeAsString, "[]") {
-			fieldSchema, err = p.ParseSchemaObject(pkgPath, pkgName, typeAsString)
-			if err != nil {
-				p.Debug(err)
-				return
-			}
-		} else if strings.HasPrefix(typeAsString, "map[]") {
-			fieldSchema, err = p.ParseSchemaObject(pkgPath, pkgName, typeAsString)
-			if err != nil {
-				p.Debug(err)
-				return
+	}
+	for _, astField := range astFields {
+		for _, astIdent := range astField.Names {
+			if !astIdent.IsExported() {
+				// encoding/json ignores the unexported fields
+				continue
 			}
-		} else if typeAsString == "time.Time" {
-			fieldSchema, err = p.ParseSchemaObject(pkgPath, pkgName, typeAsString)
-			if err != nil {
+			if err := p.parseStructField(pkgPath, pkgName, structSchema, astField, astIdent.Name); isTagError(err) {
+				return err
+			} else if err != nil {
 				p.Debug(err)
-				return
+				return nil
 			}
-		} else if strings.HasPrefix(typeAsString, "interface{}") {
-			fieldSchema, err = p.ParseSchemaObject(pkgPath, pkgName, typeAsString)
-			if err != nil {
+		}
+		if len(astField.Names) > 0 {
+			continue
+		}
+		// embedded type
+		astFieldTag := getFieldTag(astField)
+		if astFieldTag.Get("skip") == "true" {
+			continue
+		}
+		typeAsString := strings.TrimLeft(p.getTypeAsString(astField.Type), "*")
+		typeNameParts := strings.Split(typeAsString, ".")
+		name := typeNameParts[len(typeNameParts)-1]
+		isStruct := p.isStructType(pkgPath, pkgName, typeAsString)
+		if !ast.IsExported(name) && !isStruct {
+			continue
+		}
+		field := p.parseJSONField(name, typeAsString, astFieldTag)
+		if field.omitted {
+			continue
+		}
+		if field.tagged || !isStruct {
+			// a struct named by its tag is not embedded, neither are the other types
+			if err := p.parseStructField(pkgPath, pkgName, structSchema, astField, name); isTagError(err) {
+				return err
+			} else if err != nil {
 				p.Debug(err)
-				return
-			}
-		} else if !utils.IsBasicGoType(typeAsString) {
-			fieldSchemaSchemeaObjectID, err := p.RegisterType(pkgPath, pkgName, typeAsString)
-			if err != nil {
-				p.Debug("parseSchemaPropertiesFromStructFields err:", err)
-			} else {
-				fieldSchema.ID = fieldSchemaSchemeaObjectID
-				schema, ok := p.KnownIDSchema[fieldSchemaSchemeaObjectID]
-				if ok {
-					fieldSchema.Type = schema.Type
-					if schema.Items != nil {
-						fieldSchema.Items = schema.Items
-					}
-				}
-				fieldSchema.Ref = utils.AddSchemaRefLinkPrefix(fieldSchemaSchemeaObjectID)
+				return nil
 			}
-		} else if utils.IsGoTypeOASType(typeAsString) {
-			fieldSchema.Type = utils.GoTypesOASTypes[typeAsString]
+			continue
 		}
-
-		name := astField.Names[0].Name
-		fieldSchema.FieldName = name
-		_, disabled := structSchema.DisabledFieldNames[name]
-		if disabled {
+		fieldSchemaSchemeaObjectID, err := p.RegisterType(pkgPath, pkgName, typeAsString)
+		if isTagError(err) {
+			return err
+		} else if err != nil {
+			p.Debug("parseSchemaPropertiesFromStructFields err:", err)
 			continue
 		}
+		if _, ok := p.building[fieldSchemaSchemeaObjectID]; ok {
+			// the embedded type refers back to this one, its properties are copied once it is parsed
+			p.embeddings[fieldSchemaSchemeaObjectID] = append(p.embeddings[fieldSchemaSchemeaObjectID], structSchema)
+		} else if refSchema, ok := p.KnownIDSchema[fieldSchemaSchemeaObjectID]; ok {
+			p.embedProperties(structSchema, refSchema)
+		}
+	}
+	return nil
+}
 
-		if astField.Tag != nil {
-			astFieldTag := reflect.StructTag(strings.Trim(astField.Tag.Value, "`"))
-			tagText := ""
-
-			if tag := astFieldTag.Get("go-swagger3"); tag != "" {
-				tagText = tag
-			}
-
-			if skip := astFieldTag.Get("skip"); skip == "true" {
-				continue astFieldsLoop
-			}
-
-			tagValues := strings.Split(tagText, ",")
-			for _, v := range tagValues {
-				if v == "-" {
-					structSchema.DisabledFieldNames[name] = struct{}{}
-					fieldSchema.Deprecated = true
-					continue astFieldsLoop
-				}
-			}
-
-			if tag := astFieldTag.Get("json"); tag != "" {
-				tagText = tag
-			}
-			tagValues = strings.Split(tagText, ",")
-			isRequired := false
-			for _, v := range tagValues {
-				if v == "-" {
-					structSchema.DisabledFieldNames[name] = struct{}{}
-					fieldSchema.Deprecated = true
-					continue astFieldsLoop
-				} else if v == "required" {
-					isRequired = true
-				} else if v != "" && v != "required" && v != "omitempty" {
-					name = v
-				}
-			}
-			p.addType(astFieldTag, fieldSchema)
-			p.addFormat(astFieldTag, fieldSchema)
-			p.addExample(astFieldTag, fieldSchema)
-			p.addOverrideExample(astFieldTag, fieldSchema)
-			p.addRequiredField(astFieldTag, isRequired, structSchema, name)
-			p.addDescription(astFieldTag, fieldSchema)
-			p.addReference(astFieldTag, fieldSchema)
-			p.addEnum(astFieldTag, fieldSchema)
-			p.addTitle(astFieldTag, fieldSchema)
-			p.addMaxLimit(astFieldTag, fieldSchema)
-			p.addIsExclusiveMaximum(astFieldTag, fieldSchema)
-			p.addMinimumLimit(astFieldTag, fieldSchema)
-			p.addIsExclusiveMinimum(astFieldTag, fieldSchema)
-			p.addMaxLength(astFieldTag, fieldSchema)
-			p.addMinLength(astFieldTag, fieldSchema)
-			p.addPattern(astFieldTag, fieldSchema)
-			p.addMaxItems(astFieldTag, fieldSchema)
-			p.addMinItems(astFieldTag, fieldSchema)
-			p.addUniqueItems(astFieldTag, fieldSchema)
-			p.addMaxProperties(astFieldTag, fieldSchema)
-			p.addMinProperties(astFieldTag, fieldSchema)
-			p.addAdditionalProperties(astFieldTag, fieldSchema)
-			p.addNullable(astFieldTag, fieldSchema)
-			p.addReadOnly(astFieldTag, fieldSchema)
-			p.addWriteOnly(astFieldTag, fieldSchema)
+// parseStructField adds the property of a struct field, named by its json tag, its go-swagger3 tag or
+// its go name
+func (p *parser) parseStructField(pkgPath, pkgName string, structSchema *SchemaObject, astField *ast.Field, name string) error {
+	astFieldTag := getFieldTag(astField)
+	if astFieldTag.Get("skip") == "true" {
+		// If the field has a 'skip:"true"' tag, skip this iteration
+		return nil
+	}
+	typeAsString := strings.TrimLeft(p.getTypeAsString(astField.Type), "*")
+	var fieldSchema *SchemaObject
+	var err error
+	if tag := astFieldTag.Get("swaggertype"); tag != "" {
+		// the go type is not parsed, neither is it a component
+		fieldSchema, err = swaggerTypeSchema(tag)
+		if err != nil {
+			return &TagError{Schema: structSchema.ID, Field: name, Err: err}
+		}
+	} else {
+		fieldSchema, err = p.parseFieldSchemaObject(pkgPath, pkgName, structSchema, name, astField.Type)
+		if err != nil {
+			return err
 		}
-		structSchema.Properties.Set(name, fieldSchema)
 	}
-	for _, astField := range astFields {
-		if len(astField.Names) > 0 {
-			continue
+	fieldSchema.FieldName = name
+	_, disabled := structSchema.DisabledFieldNames[name]
+	if disabled {
+		return nil
+	}
+	field := p.parseJSONField(name, typeAsString, astFieldTag)
+	if field.asString {
+		encodeAsString(fieldSchema, typeAsString)
+	}
+	if astField.Tag != nil {
+		p.addType(astFieldTag, fieldSchema)
+		p.addFormat(astFieldTag, fieldSchema)
+		if err := p.addExample(astFieldTag, fieldSchema); err != nil {
+			return &TagError{Schema: structSchema.ID, Field: name, Err: err}
 		}
-		fieldSchema := &SchemaObject{}
-		typeAsString := p.getTypeAsString(astField.Type)
-		typeAsString = strings.TrimLeft(typeAsString, "*")
-		if strings.HasPrefix(typeAsString, "[]") {
-			fieldSchema, err = p.ParseSchemaObject(pkgPath, pkgName, typeAsString)
-			if err != nil {
-				p.Debug(err)
-				return
-			}
-		} else if strings.HasPrefix(typeAsString, "map[]") {
-			fieldSchema, err = p.ParseSchemaObject(pkgPath, pkgName, typeAsString)
-			if err != nil {
-				p.Debug(err)
-				return
-			}
-		} else if typeAsString == "time.Time" {
-			fieldSchema, err = p.ParseSchemaObject(pkgPath, pkgName, typeAsString)
-			if err != nil {
-				p.Debug(err)
-				return
-			}
-		} else if strings.HasPrefix(typeAsString, "interface{}") {
-			fieldSchema, err = p.ParseSchemaObject(pkgPath, pkgName, typeAsString)
-			if err != nil {
-				p.Debug(err)
-				return
+		p.addOverrideExample(astFieldTag, fieldSchema)
+		p.addDescription(astFieldTag, fieldSchema)
+		p.addReference(astFieldTag, fieldSchema)
+		p.addEnum(astFieldTag, fieldSchema)
+		p.addTitle(astFieldTag, fieldSchema)
+		p.addIsExclusiveMaximum(astFieldTag, fieldSchema)
+		p.addIsExclusiveMinimum(astFieldTag, fieldSchema)
+		p.addPattern(astFieldTag, fieldSchema)
+		p.addUniqueItems(astFieldTag, fieldSchema)
+		p.addAdditionalProperties(astFieldTag, fieldSchema)
+		p.addNullable(astFieldTag, fieldSchema)
+		p.addReadOnly(astFieldTag, fieldSchema)
+		p.addWriteOnly(astFieldTag, fieldSchema)
+		p.addXML(astFieldTag, fieldSchema, field.name)
+		p.addDiscriminator(astFieldTag, structSchema, field.name)
+		for _, add := range []func(reflect.StructTag, *SchemaObject) error{
+			p.addMaxLimit, p.addMinimumLimit, p.addMultipleOf, p.addMaxLength, p.addMinLength, p.addMaxItems,
+			p.addMinItems, p.addMaxProperties, p.addMinProperties, p.addDefault, p.addConst, p.addNot, p.addExternalDocs,
+		} {
+			if err := add(astFieldTag, fieldSchema); err != nil {
+				return &TagError{Schema: structSchema.ID, Field: name, Err: err}
 			}
-		} else if !utils.IsBasicGoType(typeAsString) {
-			fieldSchemaSchemeaObjectID, err := p.RegisterType(pkgPath, pkgName, typeAsString)
-			if err != nil {
-				p.Debug("parseSchemaPropertiesFromStructFields err:", err)
-			} else {
-				fieldSchema.ID = fieldSchemaSchemeaObjectID
-				schema, ok := p.KnownIDSchema[fieldSchemaSchemeaObjectID]
-				if ok {
-					fieldSchema.Type = schema.Type
-					if schema.Items != nil {
-						fieldSchema.Items = schema.Items
-					}
-				}
-				fieldSchema.Ref = utils.AddSchemaRefLinkPrefix(fieldSchemaSchemeaObjectID)
-			}
-		} else if utils.IsGoTypeOASType(typeAsString) {
-			fieldSchema.Type = utils.GoTypesOASTypes[typeAsString]
 		}
-		// embedded type
-		if len(astField.Names) == 0 {
-			if fieldSchema.Properties != nil {
-				for _, propertyName := range fieldSchema.Properties.Keys() {
-					_, exist := structSchema.Properties.Get(propertyName)
-					if exist {
-						continue
-					}
-					propertySchema, _ := fieldSchema.Properties.Get(propertyName)
-					structSchema.Properties.Set(propertyName, propertySchema)
-				}
-			} else if len(fieldSchema.Ref) != 0 && len(fieldSchema.ID) != 0 {
-				refSchema, ok := p.KnownIDSchema[fieldSchema.ID]
-				if ok {
-					for _, propertyName := range refSchema.Properties.Keys() {
-						refPropertySchema, _ := refSchema.Properties.Get(propertyName)
-						_, disabled := structSchema.DisabledFieldNames[refPropertySchema.(*SchemaObject).FieldName]
-						if disabled {
-							continue
-						}
-						// p.debug(">", propertyName)
-						_, exist := structSchema.Properties.Get(propertyName)
-						if exist {
-							continue
-						}
-
-						structSchema.Properties.Set(propertyName, refPropertySchema)
-					}
-					structSchema.Required = append(structSchema.Required, refSchema.Required...)
-				}
+	}
+	structField := StructField{Untagged: 1}
+	if field.tagged {
+		structField = StructField{Tagged: 1}
+	}
+	setStructField(structSchema, field.name, fieldSchema, structField, p.fieldRequiredIn(astField, astFieldTag, field))
+	return nil
+}
+
+// parseFieldSchemaObject returns the schema of the type of a struct field
+func (p *parser) parseFieldSchemaObject(pkgPath, pkgName string, structSchema *SchemaObject, name string, astType ast.Expr) (*SchemaObject, error) {
+	typeAsString := strings.TrimLeft(p.getTypeAsString(astType), "*")
+	if inlineSchema, ok, err := p.parseInlineStructSchemaObject(pkgPath, pkgName, structSchema, name, astType); ok {
+		return inlineSchema, err
+	} else if strings.HasPrefix(typeAsString, "[]") || utils.IsMapType(typeAsString) || typeAsString == "time.Time" ||
+		strings.HasPrefix(typeAsString, "interface{}") {
+		return p.ParseSchemaObject(pkgPath, pkgName, typeAsString)
+	} else if mapping, _, _, ok := p.TypeMapping(pkgName, typeAsString); ok {
+		return mapping.Schema(), nil
+	}
+	fieldSchema := &SchemaObject{}
+	if !utils.IsBasicGoType(typeAsString) {
+		fieldSchemaSchemeaObjectID, err := p.RegisterType(pkgPath, pkgName, typeAsString)
+		if isTagError(err) {
+			return nil, err
+		} else if err != nil {
+			p.Debug("parseSchemaPropertiesFromStructFields err:", err)
+			return fieldSchema, nil
+		}
+		fieldSchema.ID = fieldSchemaSchemeaObjectID
+		schema, ok := p.KnownIDSchema[fieldSchemaSchemeaObjectID]
+		if ok {
+			fieldSchema.Type = schema.Type
+			if schema.Items != nil {
+				fieldSchema.Items = schema.Items
 			}
-			continue
 		}
+		fieldSchema.Ref = utils.AddSchemaRefLinkPrefix(fieldSchemaSchemeaObjectID)
+	} else if utils.IsGoTypeOASType(typeAsString) {
+		fieldSchema.Type = utils.GoTypesOASTypes[typeAsString]
 	}
+	return fieldSchema, nil
+}
+
+// isStructType tells whether a type name, qualified or not, is a struct
+func (p *parser) isStructType(pkgPath, pkgName, typeName string) bool {
+	if utils.IsBasicGoType(typeName) {
+		return false
+	}
+	typeSpec, _, _, ok := p.lookupTypeSpec(pkgPath, pkgName, typeName)
+	if !ok {
+		return false
+	}
+	_, ok = typeSpec.Type.(*ast.StructType)
+	return ok
+}
+
+// isOmittedField tells whether a field is hidden by its json or go-swagger3 tag
+func isOmittedField(astFieldTag reflect.StructTag) bool {
+	for _, v := range strings.Split(astFieldTag.Get("go-swagger3"), ",") {
+		if v == "-" {
+			return true
+		}
+	}
+	return astFieldTag.Get("json") == "-"
+}
+
+func getFieldTag(astField *ast.Field) reflect.StructTag {
+	if astField.Tag == nil {
+		return ""
+	}
+	return reflect.StructTag(strings.Trim(astField.Tag.Value, "`"))
 }
 
 func (p *parser) addType(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
@@ -381,54 +399,42 @@ func (p *parser) addType(astFieldTag reflect.StructTag, fieldSchema *SchemaObjec
 	}
 }
 
-func (p *parser) addFormat(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
-	if tag := astFieldTag.Get("format"); tag != "" {
-		fieldSchema.Format = tag
+// swaggerTypeSchema returns the schema of a field documented as another type, eg. swaggertype:"string,uuid"
+// for a string of uuid format, swaggertype:"array,integer" for an array of integers or
+// swaggertype:"primitive,integer"
+func swaggerTypeSchema(tag string) (*SchemaObject, error) {
+	values := strings.Split(tag, ",")
+	switch values[0] {
+	case "primitive", "array":
+		if len(values) < 2 || !isSwaggerType(values[1]) {
+			return nil, fmt.Errorf("invalid swaggertype %q: %s needs the type of its values, eg. %s,integer", tag, values[0], values[0])
+		}
+		if values[0] == "array" {
+			return &SchemaObject{Type: "array", Items: &SchemaObject{Type: values[1]}}, nil
+		}
+		return &SchemaObject{Type: values[1]}, nil
 	}
+	if !isSwaggerType(values[0]) {
+		return nil, fmt.Errorf("invalid swaggertype %q: expected string, integer, number, boolean, object, array or primitive", tag)
+	}
+	schemaObject := &SchemaObject{Type: values[0]}
+	if len(values) > 1 {
+		schemaObject.Format = values[1]
+	}
+	return schemaObject, nil
 }
 
-func (p *parser) addExample(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
-	if tag := astFieldTag.Get("example"); tag != "" {
-		switch fieldSchema.Type {
-		case "boolean":
-			fieldSchema.Example, _ = strconv.ParseBool(tag)
-		case "integer":
-			fieldSchema.Example, _ = strconv.Atoi(tag)
-		case "number":
-			fieldSchema.Example, _ = strconv.ParseFloat(tag, 64)
-		case "array":
-			b, err := json.RawMessage(tag).MarshalJSON()
-			if err != nil {
-				fieldSchema.Example = "invalid example"
-			} else {
-				sliceOfInterface := []interface{}{}
-				err := json.Unmarshal(b, &sliceOfInterface)
-				if err != nil {
-					fieldSchema.Example = "invalid example"
-				} else {
-					fieldSchema.Example = sliceOfInterface
-				}
-			}
-		case "object":
-			b, err := json.RawMessage(tag).MarshalJSON()
-			if err != nil {
-				fieldSchema.Example = "invalid example"
-			} else {
-				mapOfInterface := map[string]interface{}{}
-				err := json.Unmarshal(b, &mapOfInterface)
-				if err != nil {
-					fieldSchema.Example = "invalid example"
-				} else {
-					fieldSchema.Example = mapOfInterface
-				}
-			}
-		default:
-			fieldSchema.Example = tag
-		}
+func isSwaggerType(typeName string) bool {
+	switch typeName {
+	case "string", "integer", "number", "boolean", "object":
+		return true
+	}
+	return false
+}
 
-		if fieldSchema.Example != nil && len(fieldSchema.Ref) != 0 {
-			fieldSchema.Ref = ""
-		}
+func (p *parser) addFormat(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
+	if tag := astFieldTag.Get("format"); tag != "" {
+		fieldSchema.Format = tag
 	}
 }
 
@@ -451,21 +457,26 @@ func (p *parser) addNullable(astFieldTag reflect.StructTag, fieldSchema *SchemaO
 }
 
 func (p *parser) addAdditionalProperties(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
-	if additionalProperties := astFieldTag.Get("additionalProperties"); additionalProperties == "true" {
-		fieldSchema.AdditionalProperties = true
+	additionalProperties, err := strconv.ParseBool(astFieldTag.Get("additionalProperties"))
+	if err != nil || (additionalProperties && fieldSchema.AdditionalProperties != nil && fieldSchema.AdditionalProperties.Schema != nil) {
+		// the values of a map are kept
+		return
 	}
+	fieldSchema.AdditionalProperties = AllowAdditionalProperties(additionalProperties)
 }
 
-func (p *parser) addMinProperties(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
+func (p *parser) addMinProperties(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) (err error) {
 	if minProperties := astFieldTag.Get("minProperties"); minProperties != "" {
-		fieldSchema.MinProperties = parseUint(minProperties)
+		fieldSchema.MinProperties, err = parseUint("minProperties", minProperties)
 	}
+	return err
 }
 
-func (p *parser) addMaxProperties(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
+func (p *parser) addMaxProperties(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) (err error) {
 	if maxProperties := astFieldTag.Get("maxProperties"); maxProperties != "" {
-		fieldSchema.MaxProperties = parseUint(maxProperties)
+		fieldSchema.MaxProperties, err = parseUint("maxProperties", maxProperties)
 	}
+	return err
 }
 
 func (p *parser) addUniqueItems(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
@@ -474,16 +485,18 @@ func (p *parser) addUniqueItems(astFieldTag reflect.StructTag, fieldSchema *Sche
 	}
 }
 
-func (p *parser) addMinItems(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
+func (p *parser) addMinItems(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) (err error) {
 	if minItems := astFieldTag.Get("minItems"); minItems != "" {
-		fieldSchema.MinItems = parseUint(minItems)
+		fieldSchema.MinItems, err = parseUint("minItems", minItems)
 	}
+	return err
 }
 
-func (p *parser) addMaxItems(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
+func (p *parser) addMaxItems(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) (err error) {
 	if maxItems := astFieldTag.Get("maxItems"); maxItems != "" {
-		fieldSchema.MaxItems = parseUint(maxItems)
+		fieldSchema.MaxItems, err = parseUint("maxItems", maxItems)
 	}
+	return err
 }
 
 func (p *parser) addPattern(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
@@ -492,16 +505,18 @@ func (p *parser) addPattern(astFieldTag reflect.StructTag, fieldSchema *SchemaOb
 	}
 }
 
-func (p *parser) addMinLength(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
+func (p *parser) addMinLength(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) (err error) {
 	if minLength := astFieldTag.Get("minLength"); minLength != "" {
-		fieldSchema.MinLength = parseUint(minLength)
+		fieldSchema.MinLength, err = parseUint("minLength", minLength)
 	}
+	return err
 }
 
-func (p *parser) addMaxLength(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
+func (p *parser) addMaxLength(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) (err error) {
 	if maxLength := astFieldTag.Get("maxLength"); maxLength != "" {
-		fieldSchema.MaxLength = parseUint(maxLength)
+		fieldSchema.MaxLength, err = parseUint("maxLength", maxLength)
 	}
+	return err
 }
 
 func (p *parser) addIsExclusiveMinimum(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
@@ -510,10 +525,11 @@ func (p *parser) addIsExclusiveMinimum(astFieldTag reflect.StructTag, fieldSchem
 	}
 }
 
-func (p *parser) addMinimumLimit(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
+func (p *parser) addMinimumLimit(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) (err error) {
 	if minimum := astFieldTag.Get("minimum"); minimum != "" {
-		fieldSchema.Minimum = parseFloat64(minimum)
+		fieldSchema.Minimum, err = parseFloat64("minimum", minimum)
 	}
+	return err
 }
 
 func (p *parser) addIsExclusiveMaximum(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
@@ -522,10 +538,11 @@ func (p *parser) addIsExclusiveMaximum(astFieldTag reflect.StructTag, fieldSchem
 	}
 }
 
-func (p *parser) addMaxLimit(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
+func (p *parser) addMaxLimit(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) (err error) {
 	if maximum := astFieldTag.Get("maximum"); maximum != "" {
-		fieldSchema.Maximum = parseFloat64(maximum)
+		fieldSchema.Maximum, err = parseFloat64("maximum", maximum)
 	}
+	return err
 }
 
 func (p *parser) addTitle(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
@@ -553,12 +570,6 @@ func (p *parser) addDescription(astFieldTag reflect.StructTag, fieldSchema *Sche
 	}
 }
 
-func (p *parser) addRequiredField(astFieldTag reflect.StructTag, isRequired bool, structSchema *SchemaObject, name string) {
-	if _, ok := astFieldTag.Lookup("required"); ok || isRequired {
-		structSchema.Required = append(structSchema.Required, name)
-	}
-}
-
 func (p *parser) addOverrideExample(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) {
 	if tag := astFieldTag.Get("override-example"); tag != "" {
 		fieldSchema.Example = tag
@@ -577,7 +588,7 @@ func (p *parser) getTypeAsString(fieldType interface{}) string {
 
 	astMapType, ok := fieldType.(*ast.MapType)
 	if ok {
-		return fmt.Sprintf("map[]%v", p.getTypeAsString(astMapType.Value))
+		return fmt.Sprintf("map[%v]%v", p.getTypeAsString(astMapType.Key), p.getTypeAsString(astMapType.Value))
 	}
 
 	_, ok = fieldType.(*ast.InterfaceType)
@@ -604,6 +615,10 @@ func (p *parser) getTypeAsString(fieldType interface{}) string {
 		return packageNameIdent.Name + "." + astSelectorExpr.Sel.Name
 	}
 
+	if _, ok := fieldType.(*ast.StructType); ok {
+		return "struct{}"
+	}
+
 	return fmt.Sprint(fieldType)
 }
 
@@ -615,22 +630,20 @@ func parseEnumValues(enumString string) interface{} {
 	return result
 }
 
-func parseUint(uintString string) uint {
+func parseUint(tag, uintString string) (uint, error) {
 	value, err := strconv.ParseUint(uintString, 10, 64)
 	if err != nil {
-		value = 0
+		return 0, fmt.Errorf("invalid %s %q: %v", tag, uintString, err)
 	}
-
-	return uint(value)
+	return uint(value), nil
 }
 
-func parseFloat64(float64String string) float64 {
+func parseFloat64(tag, float64String string) (float64, error) {
 	value, err := strconv.ParseFloat(float64String, 64)
 	if err != nil {
-		value = 0
+		return 0, fmt.Errorf("invalid %s %q: %v", tag, float64String, err)
 	}
-
-	return value
+	return value, nil
 }
 
 type DECL struct {
@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"go/ast"
+	"strings"
+
+	. "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/parser/model"
+	"github.com/parvez3019/go-swagger3/parser/utils"
+)
+
+// parseInlineStructSchemaObject returns the schema of an anonymous struct, or of a slice or map of
+// them, eg. Items []struct{ ID int }. It is an inline schema, or a reference to a component named after
+// the parent struct and the field when the anonymous structs are hoisted.
+func (p *parser) parseInlineStructSchemaObject(pkgPath, pkgName string, parent *SchemaObject, fieldName string, astType ast.Expr) (*SchemaObject, bool, error) {
+	switch astType := astType.(type) {
+	case *ast.StarExpr:
+		return p.parseInlineStructSchemaObject(pkgPath, pkgName, parent, fieldName, astType.X)
+	case *ast.ArrayType:
+		items, ok, err := p.parseInlineStructSchemaObject(pkgPath, pkgName, parent, fieldName, astType.Elt)
+		if !ok || err != nil {
+			return nil, ok, err
+		}
+		return &SchemaObject{Type: "array", Items: items}, true, nil
+	case *ast.MapType:
+		value, ok, err := p.parseInlineStructSchemaObject(pkgPath, pkgName, parent, fieldName, astType.Value)
+		if !ok || err != nil {
+			return nil, ok, err
+		}
+		schemaObject := &SchemaObject{Type: "object", AdditionalProperties: AdditionalPropertiesOf(value)}
+		if keyType := p.getTypeAsString(astType.Key); keyType != "string" {
+			schemaObject.KeyType = keyType
+		}
+		return schemaObject, true, nil
+	case *ast.StructType:
+		schemaObject, err := p.parseInlineStruct(pkgPath, pkgName, parent, fieldName, astType)
+		return schemaObject, true, err
+	}
+	return nil, false, nil
+}
+
+func (p *parser) parseInlineStruct(pkgPath, pkgName string, parent *SchemaObject, fieldName string, astStructType *ast.StructType) (*SchemaObject, error) {
+	schemaObject := &SchemaObject{Type: "object"}
+	var parentType *model.Type
+	if p.HoistInlineStructs && parent != nil {
+		parentType = p.KnownIDType[parent.ID]
+	}
+	if parentType == nil {
+		err := p.parseSchemaPropertiesFromStructFields(pkgPath, pkgName, schemaObject, astStructType.Fields.List)
+		return schemaObject, err
+	}
+
+	goType := &model.Type{PkgName: parentType.PkgName, Name: strings.Join([]string{parentType.Name, fieldName}, "@")}
+	if p.FileSet != nil {
+		goType.Position = p.FileSet.Position(astStructType.Pos())
+	}
+	schemaObject.ID = utils.GenSchemaObjectID(goType.PkgName, goType.Name, false)
+	schemaObject.PkgName = goType.PkgName
+	p.KnownIDSchema[schemaObject.ID] = schemaObject
+	p.KnownIDType[schemaObject.ID] = goType
+	p.OpenAPI.Components.Schemas[schemaObject.ID] = schemaObject
+	err := p.parseSchemaPropertiesFromStructFields(pkgPath, pkgName, schemaObject, astStructType.Fields.List)
+	return &SchemaObject{Ref: utils.AddSchemaRefLinkPrefix(schemaObject.ID)}, err
+}
@@ -0,0 +1,250 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+
+	. "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/parser/utils"
+)
+
+const (
+	// JSONNamingStd names the fields as encoding/json does, after their json tag or their go name
+	JSONNamingStd = "std"
+	// JSONNamingCamel names the untagged fields in lower camel case, eg. userID for UserID, as the
+	// naming strategies of encoders such as jsoniter do
+	JSONNamingCamel = "camel"
+	// JSONNamingSnake names the untagged fields in snake case, eg. user_id for UserID
+	JSONNamingSnake = "snake"
+	// JSONNamingProtojson names the fields of the protobuf messages as protojson does, after the json
+	// name of their protobuf tag, and encodes their 64 bits integers as strings
+	JSONNamingProtojson = "protojson"
+)
+
+// IsJSONNaming tells whether a json naming is known, the empty one being JSONNamingStd
+func IsJSONNaming(naming string) bool {
+	switch naming {
+	case "", JSONNamingStd, JSONNamingCamel, JSONNamingSnake, JSONNamingProtojson:
+		return true
+	}
+	return false
+}
+
+// jsonField is the name and the options a struct field is encoded with
+type jsonField struct {
+	name string
+	// tagged is true when the name is given by a tag, such a field hides the untagged ones of the
+	// same name at the same depth
+	tagged    bool
+	omitted   bool
+	omitEmpty bool
+	required  bool
+	asString  bool
+}
+
+// parseJSONField returns how a struct field is encoded from its go name, its go type and its tags,
+// the go-swagger3 tag standing for the json one when it is missing
+func (p *parser) parseJSONField(goName, typeName string, astFieldTag reflect.StructTag) jsonField {
+	tag := astFieldTag.Get("json")
+	if tag == "" {
+		tag = astFieldTag.Get("go-swagger3")
+	}
+	if tag == "-" {
+		return jsonField{omitted: true}
+	}
+	field := jsonField{}
+	tagName, options := tag, ""
+	if i := strings.Index(tag, ","); i != -1 {
+		tagName, options = tag[:i], tag[i+1:]
+	}
+	for _, option := range strings.Split(options, ",") {
+		switch option {
+		case "omitempty":
+			field.omitEmpty = true
+		case "required":
+			field.required = true
+		case "string":
+			field.asString = true
+		}
+	}
+	if protobufTag := astFieldTag.Get("protobuf"); p.Flags.JSONNaming == JSONNamingProtojson && protobufTag != "" {
+		// protojson ignores the json tags
+		field.name, field.tagged = protobufJSONName(protobufTag), true
+		field.asString = typeName == "int64" || typeName == "uint64"
+		return field
+	}
+	if tagName != "" && isValidTag(tagName) {
+		field.name, field.tagged = tagName, true
+		return field
+	}
+	switch p.Flags.JSONNaming {
+	case JSONNamingCamel:
+		field.name = lowerCamelCase(goName)
+	case JSONNamingSnake:
+		field.name = snakeCase(goName)
+	default:
+		field.name = goName
+	}
+	return field
+}
+
+// isValidTag is the check of encoding/json on the names given by the tags
+func isValidTag(name string) bool {
+	for _, r := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", r):
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			return false
+		}
+	}
+	return true
+}
+
+// protobufJSONName returns the json name of a protobuf tag, eg. userId for
+// `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3"`
+func protobufJSONName(protobufTag string) string {
+	name := ""
+	for _, value := range strings.Split(protobufTag, ",") {
+		if strings.HasPrefix(value, "json=") {
+			return strings.TrimPrefix(value, "json=")
+		}
+		if strings.HasPrefix(value, "name=") {
+			name = strings.TrimPrefix(value, "name=")
+		}
+	}
+	return lowerCamelCase(name)
+}
+
+// lowerCamelCase turns a go or a snake case name into lower camel case, eg. userID for UserID
+func lowerCamelCase(name string) string {
+	words := splitWords(name)
+	for i := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(words[i])
+		} else if strings.ToUpper(words[i]) != words[i] {
+			words[i] = strings.Title(strings.ToLower(words[i]))
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// snakeCase turns a go name into snake case, eg. user_id for UserID
+func snakeCase(name string) string {
+	words := splitWords(name)
+	for i := range words {
+		words[i] = strings.ToLower(words[i])
+	}
+	return strings.Join(words, "_")
+}
+
+// splitWords splits a name on underscores and on case changes, keeping acronyms together, eg.
+// HTTPServerID gives HTTP, Server and ID
+func splitWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '_' {
+			if start < i {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		}
+		if i == start || !unicode.IsUpper(runes[i]) {
+			continue
+		}
+		previousIsUpper := unicode.IsUpper(runes[i-1])
+		nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+		if !previousIsUpper || nextIsLower {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// encodeAsString documents a number or a boolean field with the string option as a string, eg.
+// `json:"id,string"`, encoding/json ignores the option for the other types
+func encodeAsString(fieldSchema *SchemaObject, typeName string) {
+	switch fieldSchema.Type {
+	case "integer", "number":
+		fieldSchema.Format = utils.GoTypesOASFormats[typeName]
+	case "boolean":
+	default:
+		return
+	}
+	fieldSchema.Type = "string"
+}
+
+// setStructField adds a property to a struct with the rules of encoding/json: the shallowest field of
+// a name hides the deeper ones, then the tagged one hides the untagged ones, the others hide each other
+func setStructField(structSchema *SchemaObject, name string, fieldSchema *SchemaObject, field StructField, required requiredIn) {
+	if structSchema.StructFields == nil {
+		structSchema.StructFields = map[string]StructField{}
+	}
+	existing, ok := structSchema.StructFields[name]
+	if ok && existing.Depth < field.Depth {
+		return
+	}
+	if ok && existing.Depth == field.Depth {
+		existingTagged := existing.Tagged
+		field.Tagged = capCount(existing.Tagged + field.Tagged)
+		field.Untagged = capCount(existing.Untagged + field.Untagged)
+		if field.Tagged == 1 && existingTagged == 1 {
+			// the existing field stays
+			structSchema.StructFields[name] = field
+			return
+		}
+	}
+	structSchema.StructFields[name] = field
+	removeRequired(structSchema, name)
+	if field.Tagged > 1 || (field.Tagged == 0 && field.Untagged > 1) || fieldSchema == nil {
+		structSchema.Properties.Delete(name)
+		return
+	}
+	// the property takes the place of the field it hides
+	structSchema.Properties.Delete(name)
+	structSchema.Properties.Set(name, fieldSchema)
+	addRequired(structSchema, name, required)
+}
+
+// embedProperties promotes the properties and the required fields of an embedded struct
+func (p *parser) embedProperties(structSchema, refSchema *SchemaObject) {
+	if refSchema.Properties == nil {
+		return
+	}
+	for _, propertyName := range refSchema.Properties.Keys() {
+		refPropertySchema, _ := refSchema.Properties.Get(propertyName)
+		_, disabled := structSchema.DisabledFieldNames[refPropertySchema.(*SchemaObject).FieldName]
+		if disabled {
+			continue
+		}
+		field := refSchema.StructFields[propertyName]
+		if field.Tagged+field.Untagged == 0 {
+			field.Untagged = 1
+		}
+		field.Depth++
+		setStructField(structSchema, propertyName, refPropertySchema.(*SchemaObject), field,
+			propertyRequiredIn(refSchema, propertyName))
+	}
+	for propertyName, field := range refSchema.StructFields {
+		if _, ok := refSchema.Properties.Get(propertyName); !ok {
+			// the fields hiding each other in the embedded struct hide the deeper ones too
+			field.Depth++
+			setStructField(structSchema, propertyName, nil, field, 0)
+		}
+	}
+}
+
+// capCount caps a count of fields of the same name at 2, from which they hide each other
+func capCount(count int) int {
+	if count > 2 {
+		return 2
+	}
+	return count
+}
@@ -0,0 +1,92 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"strings"
+
+	. "github.com/parvez3019/go-swagger3/openApi3Schema"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	marshalJSON = "MarshalJSON"
+	marshalText = "MarshalText"
+)
+
+// parseMarshalerSchemaObject returns the schema of a type whose wire format is not its structure: the
+// one of its @Schema annotation, eg. // @Schema {"type": "string", "pattern": "^[0-9]+$"}, a string
+// when it implements encoding.TextMarshaler or an open schema when it implements json.Marshaler
+func (p *parser) parseMarshalerSchemaObject(pkgPath, pkgName, typeName string, typeSpec *ast.TypeSpec) (*SchemaObject, error) {
+	override, err := parseSchemaAnnotation(typeSpec)
+	if err != nil {
+		return nil, fmt.Errorf("%s.%s: %v", pkgName, typeName, err)
+	}
+	if override != nil {
+		return override, nil
+	}
+	methods := p.getMarshalMethods(pkgPath, typeName)
+	if methods[marshalJSON] {
+		log.Warnf("%s.%s implements json.Marshaler, its schema is left open: add a type mapping or a @Schema annotation on the type", pkgName, typeName)
+		return &SchemaObject{}, nil
+	}
+	if methods[marshalText] {
+		return &SchemaObject{Type: "string"}, nil
+	}
+	return nil, nil
+}
+
+// parseSchemaAnnotation returns the schema given by the @Schema annotation of a type, if any
+func parseSchemaAnnotation(typeSpec *ast.TypeSpec) (*SchemaObject, error) {
+	if typeSpec == nil || typeSpec.Doc == nil {
+		return nil, nil
+	}
+	for _, comment := range typeSpec.Doc.List {
+		text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		fields := strings.Fields(text)
+		if len(fields) == 0 || strings.ToLower(fields[0]) != "@schema" {
+			continue
+		}
+		schemaObject := &SchemaObject{}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(text[len(fields[0]):])), schemaObject); err != nil {
+			return nil, fmt.Errorf("invalid @Schema annotation %s: %v", text, err)
+		}
+		return schemaObject, nil
+	}
+	return nil, nil
+}
+
+// getMarshalMethods returns the MarshalJSON and MarshalText methods declared on a type or its pointer
+func (p *parser) getMarshalMethods(pkgPath, typeName string) map[string]bool {
+	methods := map[string]bool{}
+	if pkgPath == "" {
+		return methods
+	}
+	astPkgs, err := p.GetPkgAst(pkgPath)
+	if err != nil {
+		p.Debugf("getMarshalMethods: parse of %s package cause error: %s", pkgPath, err)
+		return methods
+	}
+	for _, astPackage := range astPkgs {
+		for _, astFile := range astPackage.Files {
+			for _, astDeclaration := range astFile.Decls {
+				astFuncDeclaration, ok := astDeclaration.(*ast.FuncDecl)
+				if !ok || astFuncDeclaration.Recv == nil || len(astFuncDeclaration.Recv.List) != 1 {
+					continue
+				}
+				if name := astFuncDeclaration.Name.Name; name != marshalJSON && name != marshalText {
+					continue
+				}
+				receiver := astFuncDeclaration.Recv.List[0].Type
+				if astStarExpr, ok := receiver.(*ast.StarExpr); ok {
+					receiver = astStarExpr.X
+				}
+				if astIdent, ok := receiver.(*ast.Ident); ok && astIdent.Name == typeName {
+					methods[astFuncDeclaration.Name.Name] = true
+				}
+			}
+		}
+	}
+	return methods
+}
@@ -20,12 +20,19 @@ type Parser interface {
 type parser struct {
 	model.Utils
 	OpenAPI *OpenAPIObject
+
+	// building is the schemas of the types being parsed, a reference to one of them is a back edge
+	building map[string]*SchemaObject
+	// embeddings is the struct schemas embedding a type being parsed, by its schema id
+	embeddings map[string][]*SchemaObject
 }
 
 func NewParser(utils model.Utils, openAPIObject *OpenAPIObject) Parser {
 	return &parser{
-		Utils:   utils,
-		OpenAPI: openAPIObject,
+		Utils:      utils,
+		OpenAPI:    openAPIObject,
+		building:   map[string]*SchemaObject{},
+		embeddings: map[string][]*SchemaObject{},
 	}
 }
 
@@ -37,7 +44,11 @@ func (p *parser) GetPkgAst(pkgPath string) (map[string]*ast.Package, error) {
 		name := info.Name()
 		return !info.IsDir() && !strings.HasPrefix(name, ".") && strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go")
 	}
-	astPackages, err := goParser.ParseDir(token.NewFileSet(), pkgPath, ignoreFileFilter, goParser.ParseComments)
+	fileSet := p.FileSet
+	if fileSet == nil {
+		fileSet = token.NewFileSet()
+	}
+	astPackages, err := goParser.ParseDir(fileSet, pkgPath, ignoreFileFilter, goParser.ParseComments)
 	if err != nil {
 		return nil, err
 	}
@@ -50,12 +61,29 @@ func (p *parser) RegisterType(pkgPath, pkgName, typeName string) (string, error)
 
 	if utils.IsBasicGoType(typeName) || utils.IsInterfaceType(typeName) {
 		registerTypeName = typeName
-	} else if schemaObject, ok := p.KnownIDSchema[utils.GenSchemaObjectID(pkgName, typeName, p.SchemaWithoutPkg)]; ok {
-		_, ok := p.OpenAPI.Components.Schemas[utils.ReplaceBackslash(typeName)]
+	} else if mapping, importPath, name, ok := p.TypeMapping(pkgName, typeName); ok {
+		// a mapped type is a component of its own so that the operations can reference it
+		registerTypeName = name
+		if importPath != "" {
+			registerTypeName = utils.GenSchemaObjectID(importPath, name, false)
+		}
+		if _, ok := p.OpenAPI.Components.Schemas[registerTypeName]; !ok {
+			p.OpenAPI.Components.Schemas[registerTypeName] = mapping.Schema()
+		}
+		if p.KnownIDType != nil {
+			p.KnownIDType[registerTypeName] = &model.Type{PkgName: importPath, Name: name}
+		}
+	} else if schemaObject, ok := p.KnownIDSchema[utils.GenSchemaObjectID(pkgName, typeName, false)]; ok {
+		key := utils.ReplaceBackslash(typeName)
+		if strings.Contains(key, "@") {
+			// a type declared in a function
+			key = utils.GenSchemaObjectID(pkgName, typeName, true)
+		}
+		_, ok := p.OpenAPI.Components.Schemas[key]
 		if !ok {
-			p.OpenAPI.Components.Schemas[utils.ReplaceBackslash(typeName)] = schemaObject
+			p.OpenAPI.Components.Schemas[key] = schemaObject
 		}
-		return utils.GenSchemaObjectID(pkgName, typeName, p.SchemaWithoutPkg), nil
+		return utils.GenSchemaObjectID(pkgName, typeName, false), nil
 	} else {
 		schemaObject, err := p.ParseSchemaObject(pkgPath, pkgName, typeName)
 		if err != nil {
@@ -71,6 +99,9 @@ func (p *parser) RegisterType(pkgPath, pkgName, typeName string) (string, error)
 }
 
 func (p *parser) ParseSchemaObject(pkgPath, pkgName, typeName string) (*SchemaObject, error) {
+	if mapping, _, _, ok := p.TypeMapping(pkgName, typeName); ok {
+		return mapping.Schema(), nil
+	}
 	schemaObject, err, isBasicType := p.parseBasicTypeSchemaObject(pkgPath, pkgName, typeName)
 	if isBasicType {
 		return schemaObject, err
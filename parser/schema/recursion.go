@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"strings"
+
+	. "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/parser/utils"
+)
+
+// beginSchemaObject registers the schema of a type before its fields are parsed, so that the types
+// referring back to it, directly or through other types, get a reference to it
+func (p *parser) beginSchemaObject(schemaObject *SchemaObject) {
+	p.KnownIDSchema[schemaObject.ID] = schemaObject
+	p.building[schemaObject.ID] = schemaObject
+}
+
+// endSchemaObject completes the structs which embed a type while it was being parsed
+func (p *parser) endSchemaObject(schemaObject *SchemaObject) {
+	delete(p.building, schemaObject.ID)
+	for _, structSchema := range p.embeddings[schemaObject.ID] {
+		p.embedProperties(structSchema, schemaObject)
+	}
+	delete(p.embeddings, schemaObject.ID)
+}
+
+// buildingSchemaObjectID returns the schema id of a type being parsed, eg. the items of
+// type Node struct { Children []Node }, which are referenced rather than inlined
+func (p *parser) buildingSchemaObjectID(pkgPath, pkgName, typeName string) (string, bool) {
+	if len(p.building) == 0 || strings.HasPrefix(typeName, "[]") || utils.IsMapType(typeName) ||
+		utils.IsBasicGoType(typeName) || utils.IsGoTypeOASType(typeName) || utils.IsInterfaceType(typeName) {
+		return "", false
+	}
+	_, _, pkgName, ok := p.lookupTypeSpec(pkgPath, pkgName, typeName)
+	if !ok {
+		return "", false
+	}
+	schemaObjectID := utils.GenSchemaObjectID(pkgName, typeName, false)
+	_, ok = p.building[schemaObjectID]
+	return schemaObjectID, ok
+}
@@ -0,0 +1,90 @@
+package schema
+
+import (
+	"go/ast"
+	"reflect"
+	"strings"
+
+	. "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/parser/model"
+	"github.com/parvez3019/go-swagger3/parser/utils"
+)
+
+// requiredIn is the directions a property is required in
+type requiredIn int
+
+const (
+	requiredInRequest requiredIn = 1 << iota
+	requiredInResponse
+
+	requiredInBoth = requiredInRequest | requiredInResponse
+)
+
+// fieldRequiredIn returns the directions a struct field is required in by the required policy
+func (p *parser) fieldRequiredIn(astField *ast.Field, astFieldTag reflect.StructTag, field jsonField) requiredIn {
+	if _, ok := astFieldTag.Lookup("required"); ok || field.required {
+		return requiredInBoth
+	}
+	var in requiredIn
+	if isRequiredByRules(p.RequiredRules.Request, astField, astFieldTag, field) {
+		in |= requiredInRequest
+	}
+	if isRequiredByRules(p.RequiredRules.Response, astField, astFieldTag, field) {
+		in |= requiredInResponse
+	}
+	return in
+}
+
+func isRequiredByRules(rules map[string]bool, astField *ast.Field, astFieldTag reflect.StructTag, field jsonField) bool {
+	if rules[model.RequiredValidate] &&
+		(utils.IsInStringList(strings.Split(astFieldTag.Get("validate"), ","), "required") ||
+			utils.IsInStringList(strings.Split(astFieldTag.Get("binding"), ","), "required")) {
+		return true
+	}
+	_, isPointer := astField.Type.(*ast.StarExpr)
+	return rules[model.RequiredNonPointer] && !isPointer && !field.omitEmpty
+}
+
+// propertyRequiredIn returns the directions a property of a struct is required in
+func propertyRequiredIn(structSchema *SchemaObject, name string) requiredIn {
+	if utils.IsInStringList(structSchema.Required, name) {
+		return requiredInBoth
+	}
+	var in requiredIn
+	if utils.IsInStringList(structSchema.RequestRequired, name) {
+		in |= requiredInRequest
+	}
+	if utils.IsInStringList(structSchema.ResponseRequired, name) {
+		in |= requiredInResponse
+	}
+	return in
+}
+
+// addRequired makes a property of a struct required in some directions
+func addRequired(structSchema *SchemaObject, name string, in requiredIn) {
+	switch in {
+	case requiredInBoth:
+		structSchema.Required = append(structSchema.Required, name)
+	case requiredInRequest:
+		structSchema.RequestRequired = append(structSchema.RequestRequired, name)
+	case requiredInResponse:
+		structSchema.ResponseRequired = append(structSchema.ResponseRequired, name)
+	}
+}
+
+// removeRequired makes a property of a struct optional
+func removeRequired(structSchema *SchemaObject, name string) {
+	structSchema.Required = removeName(structSchema.Required, name)
+	structSchema.RequestRequired = removeName(structSchema.RequestRequired, name)
+	structSchema.ResponseRequired = removeName(structSchema.ResponseRequired, name)
+}
+
+func removeName(names []string, name string) []string {
+	kept := names[:0]
+	for _, current := range names {
+		if current != name {
+			kept = append(kept, current)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,193 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	. "github.com/parvez3019/go-swagger3/openApi3Schema"
+)
+
+// TagError is an invalid value of a struct tag, it fails the parsing instead of documenting a zero
+type TagError struct {
+	Schema string
+	Field  string
+	Err    error
+}
+
+func (e *TagError) Error() string {
+	if e.Schema == "" {
+		return fmt.Sprintf("field %s: %v", e.Field, e.Err)
+	}
+	return fmt.Sprintf("field %s of %s: %v", e.Field, e.Schema, e.Err)
+}
+
+// isTagError tells whether an error is a TagError, which the callers ignoring the types they can not
+// parse pass on
+func isTagError(err error) bool {
+	var tagError *TagError
+	return errors.As(err, &tagError)
+}
+
+// parseTagValue parses the value of a tag into the type of a schema, eg. 10 for an integer or
+// ["a","b"] for an array, the values of the other types and of the references are kept as text
+func parseTagValue(fieldSchema *SchemaObject, tag string) (interface{}, error) {
+	switch fieldSchema.Type {
+	case "boolean":
+		return strconv.ParseBool(tag)
+	case "integer":
+		return strconv.ParseInt(tag, 10, 64)
+	case "number":
+		return strconv.ParseFloat(tag, 64)
+	case "array":
+		value := []interface{}{}
+		err := json.Unmarshal([]byte(tag), &value)
+		return value, err
+	case "object":
+		value := map[string]interface{}{}
+		err := json.Unmarshal([]byte(tag), &value)
+		return value, err
+	}
+	return tag, nil
+}
+
+func (p *parser) addDefault(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) error {
+	tag, ok := astFieldTag.Lookup("default")
+	if !ok {
+		return nil
+	}
+	value, err := parseTagValue(fieldSchema, tag)
+	if err != nil {
+		return fmt.Errorf("invalid default %q: %v", tag, err)
+	}
+	fieldSchema.Default = value
+	return nil
+}
+
+func (p *parser) addExample(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) error {
+	tag := astFieldTag.Get("example")
+	if tag == "" {
+		return nil
+	}
+	value, err := parseTagValue(fieldSchema, tag)
+	if err != nil {
+		return fmt.Errorf("invalid example %q: %v", tag, err)
+	}
+	fieldSchema.Example = value
+	fieldSchema.Ref = ""
+	return nil
+}
+
+// addConst documents the only value of a field as an enum of one value, OpenAPI 3.0 has no const
+// keyword, the json schemas get it back
+func (p *parser) addConst(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) error {
+	tag, ok := astFieldTag.Lookup("const")
+	if !ok {
+		return nil
+	}
+	value, err := parseTagValue(fieldSchema, tag)
+	if err != nil {
+		return fmt.Errorf("invalid const %q: %v", tag, err)
+	}
+	fieldSchema.Enum = []interface{}{value}
+	return nil
+}
+
+// addNot documents the values a field can not take, eg. not:"deleted,archived", as a not schema
+// with their enum
+func (p *parser) addNot(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) error {
+	tag := astFieldTag.Get("not")
+	if tag == "" {
+		return nil
+	}
+	switch fieldSchema.Type {
+	case "string", "integer", "number", "boolean":
+	default:
+		return fmt.Errorf("invalid not %q: only the values of the strings, numbers and booleans can be excluded", tag)
+	}
+	var values []interface{}
+	for _, text := range strings.Split(tag, EnumValueSeparator) {
+		value, err := parseTagValue(fieldSchema, text)
+		if err != nil {
+			return fmt.Errorf("invalid not %q: %v", tag, err)
+		}
+		values = append(values, value)
+	}
+	fieldSchema.Not = &SchemaObject{Enum: values}
+	return nil
+}
+
+func (p *parser) addMultipleOf(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) error {
+	tag := astFieldTag.Get("multipleOf")
+	if tag == "" {
+		return nil
+	}
+	multipleOf, err := parseFloat64("multipleOf", tag)
+	if err != nil {
+		return err
+	}
+	if multipleOf <= 0 {
+		return fmt.Errorf("invalid multipleOf %q: must be greater than 0", tag)
+	}
+	fieldSchema.MultipleOf = multipleOf
+	return nil
+}
+
+// addExternalDocs links the documentation of a field, eg. externalDocs:"https://example.com/ids,How ids are made"
+func (p *parser) addExternalDocs(astFieldTag reflect.StructTag, fieldSchema *SchemaObject) error {
+	tag := astFieldTag.Get("externalDocs")
+	if tag == "" {
+		return nil
+	}
+	externalDocs := &ExternalDocsObject{URL: tag}
+	if i := strings.Index(tag, ","); i != -1 {
+		externalDocs.URL, externalDocs.Description = tag[:i], tag[i+1:]
+	}
+	if externalDocs.URL == "" {
+		return fmt.Errorf("invalid externalDocs %q: the url is missing", tag)
+	}
+	fieldSchema.ExternalDocs = externalDocs
+	return nil
+}
+
+// addXML documents how encoding/xml writes a field from its xml tag, eg. xml:"id,attr" for an
+// attribute or xml:"tags>tag" for a list wrapped in a tags element, the name is only documented when
+// it is not the name of the property
+func (p *parser) addXML(astFieldTag reflect.StructTag, fieldSchema *SchemaObject, propertyName string) {
+	tag := astFieldTag.Get("xml")
+	if tag == "" || tag == "-" {
+		return
+	}
+	values := strings.Split(tag, ",")
+	xml := &XMLObject{}
+	for _, option := range values[1:] {
+		if option == "attr" {
+			xml.Attribute = true
+		}
+	}
+	names := strings.Split(values[0], ">")
+	if fieldSchema.Type == "array" && fieldSchema.Items != nil && len(names) > 1 {
+		items := *fieldSchema.Items
+		items.XML = &XMLObject{Name: names[len(names)-1]}
+		fieldSchema.Items = &items
+		xml.Name, xml.Wrapped = names[len(names)-2], true
+	} else {
+		xml.Name = names[len(names)-1]
+	}
+	if xml.Name == propertyName && !xml.Wrapped {
+		xml.Name = ""
+	}
+	if *xml != (XMLObject{}) {
+		fieldSchema.XML = xml
+	}
+}
+
+// addDiscriminator makes a field tagged discriminator:"true" the discriminator of its struct
+func (p *parser) addDiscriminator(astFieldTag reflect.StructTag, structSchema *SchemaObject, propertyName string) {
+	if astFieldTag.Get("discriminator") == "true" {
+		structSchema.Discriminator = &DiscriminatorObject{PropertyName: propertyName}
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"bufio"
 	"log"
 	"os"
+	"reflect"
+	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -99,6 +102,46 @@ func IsInterfaceType(typeName string) bool {
 	return strings.EqualFold(typeName, "interface{}")
 }
 
+// IsMapType tells if a type is a map, eg. map[string]model.User or map[]model.User
+func IsMapType(typeName string) bool {
+	_, _, ok := SplitMapType(typeName)
+	return ok
+}
+
+// SplitMapType returns the key and value types of a map type, eg. string and model.User for
+// map[string]model.User. The key type of map[]model.User is empty.
+func SplitMapType(typeName string) (keyType, valueType string, ok bool) {
+	if !strings.HasPrefix(typeName, "map[") {
+		return "", "", false
+	}
+	depth := 0
+	for i := len("map"); i < len(typeName); i++ {
+		switch typeName[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return typeName[len("map["):i], typeName[i+1:], true
+			}
+		}
+	}
+	return "", "", false
+}
+
+var arrayLengthRegexp = regexp.MustCompile(`(map)?\[[\w.]*\]`)
+
+// ReplaceArrayLengths replaces the length of the arrays of a type by [], keeping the key of the maps,
+// eg. map[string][2]int is map[string][]int
+func ReplaceArrayLengths(typeName string) string {
+	return arrayLengthRegexp.ReplaceAllStringFunc(typeName, func(brackets string) string {
+		if strings.HasPrefix(brackets, "map") {
+			return brackets
+		}
+		return "[]"
+	})
+}
+
 func IsEnumType(name string) bool {
 	return strings.Contains(name, "Enum")
 }
@@ -136,6 +179,17 @@ func IsInStringList(list []string, s string) bool {
 	return false
 }
 
+// SortedKeys returns the keys of a map with string keys in order, eg. the paths or the component
+// schemas of a spec
+func SortedKeys(m interface{}) []string {
+	var keys []string
+	for _, key := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func IsBasicGoType(typeName string) bool {
 	_, ok := BasicGoTypes[typeName]
 	return ok
@@ -163,10 +217,21 @@ func AddParametersRefLinkPrefix(name string) string {
 func GenSchemaObjectID(pkgName, typeName string, withoutPkg bool) string {
 	typeNameParts := strings.Split(typeName, ".")
 	pkgName = ReplaceBackslash(pkgName)
+	name := joinScopedTypeName(typeNameParts[len(typeNameParts)-1])
 	if withoutPkg {
-		return typeNameParts[len(typeNameParts)-1]
+		return name
+	}
+	return strings.Join(append(strings.Split(pkgName, "/"), name), ".")
+}
+
+// joinScopedTypeName joins the name of a type declared in a function or in a struct with its scope, eg.
+// GetUser@response is GetUserResponse and User@Address is UserAddress
+func joinScopedTypeName(typeName string) string {
+	parts := strings.Split(typeName, "@")
+	for i := 1; i < len(parts); i++ {
+		parts[i] = strings.Title(parts[i])
 	}
-	return strings.Join(append(strings.Split(pkgName, "/"), typeNameParts[len(typeNameParts)-1]), ".")
+	return strings.Join(parts, "")
 }
 
 func ReplaceBackslash(origin string) string {
@@ -0,0 +1,209 @@
+package parser
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/iancoleman/orderedmap"
+	. "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/parser/model"
+	"github.com/parvez3019/go-swagger3/parser/utils"
+)
+
+// InputSchemaSuffix is appended to the name of the request variant of a schema, eg. UserInput
+const InputSchemaSuffix = "Input"
+
+// variants makes the request and the response variants of the schemas
+type variants struct {
+	// inputKeys is the key of the request variant of the schemas reached in both directions, by the
+	// key of the schema
+	inputKeys map[string]string
+}
+
+// splitDirections drops the readOnly properties from the schemas the request bodies reach and the
+// writeOnly ones from the schemas the responses reach. The components reached in both directions
+// get a request variant the request bodies refer to instead.
+func (p *parser) splitDirections() {
+	requests, responses := p.reachedSchemas()
+	schemas := p.OpenAPI.Components.Schemas
+	keys := make([]string, 0, len(schemas))
+	for key := range schemas {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	// RegisterType stores the schemas under their type name too, the schema id is preferred
+	keyOf := map[*SchemaObject]string{}
+	for _, key := range keys {
+		schema := schemas[key]
+		_, known := p.KnownIDType[key]
+		_, knownKey := p.KnownIDType[keyOf[schema]]
+		if keyOf[schema] == "" || (known && !knownKey) {
+			keyOf[schema] = key
+		}
+	}
+
+	differing := map[*SchemaObject]bool{}
+	for schema := range keyOf {
+		differing[schema] = walkInline(schema, func(s *SchemaObject) bool {
+			return len(s.RequestRequired) != 0 || len(s.ResponseRequired) != 0 || hasDirectionalProperty(s)
+		})
+	}
+	for changed := true; changed; {
+		changed = false
+		for schema := range keyOf {
+			if differing[schema] {
+				continue
+			}
+			differing[schema] = walkInline(schema, func(s *SchemaObject) bool {
+				target, ok := p.OpenAPI.SchemaByRef(s.Ref)
+				return ok && differing[target]
+			})
+			changed = changed || differing[schema]
+		}
+	}
+
+	v := variants{inputKeys: map[string]string{}}
+	for _, key := range keys {
+		schema := schemas[key]
+		if differing[schema] && requests[schema] && responses[schema] {
+			v.inputKeys[key] = keyOf[schema] + InputSchemaSuffix
+		}
+	}
+
+	replaced := map[*SchemaObject]*SchemaObject{}
+	for _, key := range keys {
+		schema := schemas[key]
+		if !differing[schema] {
+			continue
+		}
+		if _, ok := replaced[schema]; !ok {
+			switch {
+			case requests[schema] && responses[schema]:
+				inputKey := v.inputKeys[key]
+				schemas[inputKey] = v.variant(schema, true)
+				if goType, ok := p.KnownIDType[keyOf[schema]]; ok {
+					p.KnownIDType[inputKey] = &model.Type{
+						PkgName:  goType.PkgName,
+						Name:     goType.Name + InputSchemaSuffix,
+						Position: goType.Position,
+						Of:       keyOf[schema],
+					}
+				}
+				replaced[schema] = v.variant(schema, false)
+			case requests[schema]:
+				replaced[schema] = v.variant(schema, true)
+			case responses[schema]:
+				replaced[schema] = v.variant(schema, false)
+			default:
+				replaced[schema] = schema
+			}
+		}
+		schemas[key] = replaced[schema]
+	}
+
+	for _, item := range p.OpenAPI.Paths {
+		for _, method := range Methods {
+			op := item.Operation(method)
+			if op == nil {
+				continue
+			}
+			for i := range op.Parameters {
+				op.Parameters[i].Schema = v.variant(op.Parameters[i].Schema, true)
+			}
+			if op.RequestBody != nil {
+				for _, mediaType := range op.RequestBody.Content {
+					if mediaType != nil {
+						mediaType.Schema = *v.variant(&mediaType.Schema, true)
+					}
+				}
+			}
+			for _, response := range op.Responses {
+				if response == nil {
+					continue
+				}
+				for _, mediaType := range response.Content {
+					if mediaType != nil {
+						mediaType.Schema = *v.variant(&mediaType.Schema, false)
+					}
+				}
+			}
+		}
+	}
+}
+
+// variant returns a copy of a schema and of the schemas it holds without the readOnly properties
+// when it is a request, without the writeOnly ones otherwise. The references of the requests are
+// rewritten to the request variants.
+func (v variants) variant(schema *SchemaObject, request bool) *SchemaObject {
+	if schema == nil {
+		return nil
+	}
+	copied := *schema
+	if inputKey, ok := v.inputKeys[strings.TrimPrefix(schema.Ref, SchemaRefPrefix)]; ok && request && schema.Ref != "" {
+		copied.Ref = SchemaRefPrefix + inputKey
+	}
+	copied.Items = v.variant(schema.Items, request)
+	copied.Not = v.variant(schema.Not, request)
+	if schema.AdditionalProperties != nil {
+		additionalProperties := *schema.AdditionalProperties
+		additionalProperties.Schema = v.variant(additionalProperties.Schema, request)
+		copied.AdditionalProperties = &additionalProperties
+	}
+	if schema.Properties == nil {
+		return &copied
+	}
+	copied.Properties = orderedmap.New()
+	for _, name := range schema.Properties.Keys() {
+		property, ok := schema.PropertySchema(name)
+		if !ok {
+			value, _ := schema.Properties.Get(name)
+			copied.Properties.Set(name, value)
+			continue
+		}
+		if (request && property.ReadOnly) || (!request && property.WriteOnly) {
+			continue
+		}
+		copied.Properties.Set(name, v.variant(property, request))
+	}
+	copied.Required = nil
+	for _, name := range schema.Required {
+		if _, ok := copied.Properties.Get(name); ok && !utils.IsInStringList(copied.Required, name) {
+			copied.Required = append(copied.Required, name)
+		}
+	}
+	return &copied
+}
+
+// walkInline tells whether visit is true for a schema or one of the schemas it holds, the references
+// are not followed
+func walkInline(schema *SchemaObject, visit func(*SchemaObject) bool) bool {
+	if schema == nil {
+		return false
+	}
+	if visit(schema) || walkInline(schema.Items, visit) || walkInline(schema.Not, visit) {
+		return true
+	}
+	if schema.AdditionalProperties != nil && walkInline(schema.AdditionalProperties.Schema, visit) {
+		return true
+	}
+	if schema.Properties != nil {
+		for _, name := range schema.Properties.Keys() {
+			if property, ok := schema.PropertySchema(name); ok && walkInline(property, visit) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasDirectionalProperty(schema *SchemaObject) bool {
+	if schema.Properties == nil {
+		return false
+	}
+	for _, name := range schema.Properties.Keys() {
+		if property, ok := schema.PropertySchema(name); ok && (property.ReadOnly || property.WriteOnly) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,369 @@
+package postman
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/parvez3019/go-swagger3/mock"
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	SchemaURL = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+	// BaseURLVariable is the variable every request url starts with, set to the first server
+	BaseURLVariable = "baseUrl"
+	// defaultFolder groups the operations without tag
+	defaultFolder = "default"
+)
+
+// Collection is a Postman collection in the v2.1 format, Insomnia imports it too
+type Collection struct {
+	Info     Info       `json:"info"`
+	Item     []Item     `json:"item"`
+	Auth     *Auth      `json:"auth,omitempty"`
+	Variable []Variable `json:"variable,omitempty"`
+}
+
+type Info struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+// Item is a folder when it holds items, a request otherwise
+type Item struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Item        []Item   `json:"item,omitempty"`
+	Request     *Request `json:"request,omitempty"`
+}
+
+type Request struct {
+	Method      string     `json:"method"`
+	Header      []KeyValue `json:"header"`
+	URL         URL        `json:"url"`
+	Body        *Body      `json:"body,omitempty"`
+	Description string     `json:"description,omitempty"`
+}
+
+type URL struct {
+	Raw      string     `json:"raw"`
+	Host     []string   `json:"host"`
+	Path     []string   `json:"path"`
+	Query    []KeyValue `json:"query,omitempty"`
+	Variable []KeyValue `json:"variable,omitempty"`
+}
+
+type KeyValue struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+type Body struct {
+	Mode     string       `json:"mode"`
+	Raw      string       `json:"raw,omitempty"`
+	FormData []KeyValue   `json:"formdata,omitempty"`
+	Options  *BodyOptions `json:"options,omitempty"`
+}
+
+type BodyOptions struct {
+	Raw struct {
+		Language string `json:"language"`
+	} `json:"raw"`
+}
+
+// Auth is the authentication of the collection, eg. {"type": "bearer", "bearer": [...]}
+type Auth struct {
+	Type   string     `json:"type"`
+	Bearer []KeyValue `json:"bearer,omitempty"`
+	Basic  []KeyValue `json:"basic,omitempty"`
+	APIKey []KeyValue `json:"apikey,omitempty"`
+	OAuth2 []KeyValue `json:"oauth2,omitempty"`
+}
+
+type Variable struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// WriteCollection writes the Postman collection of the spec as json
+func WriteCollection(openAPI *oas.OpenAPIObject, path string) error {
+	log.Infof("Writing postman collection to %s ...", path)
+	output, err := json.MarshalIndent(NewCollection(openAPI), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("Can not create the directory %s: %v", filepath.Dir(path), err)
+	}
+	if err := ioutil.WriteFile(path, output, 0644); err != nil {
+		return fmt.Errorf("Can not create the file %s: %v", path, err)
+	}
+	return nil
+}
+
+// NewCollection converts every operation into a request of the folder of its first tag. The params
+// are filled with their example and the bodies with an example built from their schema, the urls
+// start with {{baseUrl}} and the global security requirement becomes the auth of the collection.
+func NewCollection(openAPI *oas.OpenAPIObject) Collection {
+	c := Collection{Info: Info{
+		Name:        openAPI.Info.Title,
+		Description: strings.TrimSpace(openAPI.Info.Description),
+		Version:     openAPI.Info.Version,
+		Schema:      SchemaURL,
+	}}
+	for i, server := range openAPI.Servers {
+		key := BaseURLVariable
+		if i != 0 {
+			key += strconv.Itoa(i + 1)
+		}
+		c.Variable = append(c.Variable, Variable{Key: key, Value: strings.TrimSuffix(server.URL, "/"), Type: "string", Description: strings.TrimSpace(server.Description)})
+	}
+	if len(c.Variable) == 0 {
+		c.Variable = append(c.Variable, Variable{Key: BaseURLVariable, Value: "", Type: "string"})
+	}
+	c.Auth, c.Variable = auth(openAPI, openAPI.Security, c.Variable)
+
+	folders := map[string][]Item{}
+	var folderNames []string
+	paths := make([]string, 0, len(openAPI.Paths))
+	for path := range openAPI.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		for _, method := range oas.Methods {
+			op := openAPI.Paths[path].Operation(method)
+			if op == nil {
+				continue
+			}
+			folder := defaultFolder
+			if len(op.Tags) != 0 {
+				folder = op.Tags[0]
+			}
+			if _, ok := folders[folder]; !ok {
+				folderNames = append(folderNames, folder)
+			}
+			folders[folder] = append(folders[folder], requestItem(openAPI, method, path, op))
+		}
+	}
+
+	descriptions := map[string]string{}
+	for _, tag := range openAPI.Tags {
+		descriptions[tag.Name] = tag.Description
+	}
+	sort.Strings(folderNames)
+	for _, name := range folderNames {
+		c.Item = append(c.Item, Item{Name: name, Description: descriptions[name], Item: folders[name]})
+	}
+	return c
+}
+
+func requestItem(openAPI *oas.OpenAPIObject, method, path string, op *oas.OperationObject) Item {
+	name := op.Summary
+	if name == "" {
+		name = op.OperationID
+	}
+	if name == "" {
+		name = method + " " + path
+	}
+	request := &Request{
+		Method:      method,
+		Header:      []KeyValue{},
+		Description: strings.TrimSpace(op.Description),
+		URL:         URL{Host: []string{"{{" + BaseURLVariable + "}}"}, Path: []string{}},
+	}
+
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		// postman marks the path variables with a colon, "{id}" -> ":id"
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segment = ":" + strings.TrimSuffix(strings.TrimPrefix(segment, "{"), "}")
+		}
+		request.URL.Path = append(request.URL.Path, segment)
+	}
+	for i := range op.Parameters {
+		parameter := openAPI.ResolveParameter(&op.Parameters[i])
+		value := KeyValue{Key: parameter.Name, Value: parameterValue(openAPI, parameter), Description: strings.TrimSpace(parameter.Description)}
+		switch parameter.In {
+		case "path":
+			request.URL.Variable = append(request.URL.Variable, value)
+		case "query":
+			value.Disabled = !parameter.Required && value.Value == ""
+			request.URL.Query = append(request.URL.Query, value)
+		case "header":
+			value.Disabled = !parameter.Required && value.Value == ""
+			request.Header = append(request.Header, value)
+		}
+	}
+
+	if op.RequestBody != nil {
+		request.Body, request.Header = body(openAPI, op.RequestBody, request.Header)
+	}
+
+	raw := "{{" + BaseURLVariable + "}}"
+	if len(request.URL.Path) != 0 {
+		raw += "/" + strings.Join(request.URL.Path, "/")
+	}
+	var query []string
+	for _, value := range request.URL.Query {
+		if !value.Disabled {
+			query = append(query, value.Key+"="+value.Value)
+		}
+	}
+	if len(query) != 0 {
+		raw += "?" + strings.Join(query, "&")
+	}
+	request.URL.Raw = raw
+	return Item{Name: name, Request: request}
+}
+
+// parameterValue is the example of the parameter, or of its schema
+func parameterValue(openAPI *oas.OpenAPIObject, parameter *oas.ParameterObject) string {
+	example := parameter.Example
+	if example == nil && parameter.Schema != nil {
+		example = openAPI.ResolveSchema(parameter.Schema).Example
+	}
+	switch value := example.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	}
+	out, err := json.Marshal(example)
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
+
+func body(openAPI *oas.OpenAPIObject, requestBody *oas.RequestBodyObject, header []KeyValue) (*Body, []KeyValue) {
+	if mediaType, ok := requestBody.Content[oas.ContentTypeJson]; ok {
+		b := &Body{Mode: "raw", Options: &BodyOptions{}}
+		b.Options.Raw.Language = "json"
+		if out, err := json.MarshalIndent(mock.Example(openAPI, &mediaType.Schema), "", "  "); err == nil {
+			b.Raw = string(out)
+		}
+		return b, append(header, KeyValue{Key: "Content-Type", Value: oas.ContentTypeJson})
+	}
+	if mediaType, ok := requestBody.Content[oas.ContentTypeForm]; ok {
+		b := &Body{Mode: "formdata", FormData: []KeyValue{}}
+		schema := openAPI.ResolveSchema(&mediaType.Schema)
+		if schema.Properties != nil {
+			for _, name := range schema.Properties.Keys() {
+				property, _ := schema.PropertySchema(name)
+				if property.Format == "binary" {
+					b.FormData = append(b.FormData, KeyValue{Key: name, Type: "file", Description: property.Description})
+					continue
+				}
+				b.FormData = append(b.FormData, KeyValue{Key: name, Value: fmt.Sprint(mock.Example(openAPI, property)), Type: "text", Description: property.Description})
+			}
+		}
+		return b, header
+	}
+	for contentType, mediaType := range requestBody.Content {
+		example := mock.Example(openAPI, &mediaType.Schema)
+		return &Body{Mode: "raw", Raw: fmt.Sprint(example)}, append(header, KeyValue{Key: "Content-Type", Value: contentType})
+	}
+	return nil, header
+}
+
+// auth maps the first security requirement to a postman auth, the credentials are variables added to the collection
+func auth(openAPI *oas.OpenAPIObject, security []map[string][]string, variables []Variable) (*Auth, []Variable) {
+	for _, requirement := range security {
+		names := make([]string, 0, len(requirement))
+		for name := range requirement {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			scheme, ok := openAPI.Components.SecuritySchemes[name]
+			if !ok || scheme == nil {
+				continue
+			}
+			variable := func(key string) string {
+				variables = append(variables, Variable{Key: key, Value: "", Type: "string", Description: strings.TrimSpace(scheme.Description)})
+				return "{{" + key + "}}"
+			}
+			switch {
+			case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "basic"):
+				return &Auth{Type: "basic", Basic: []KeyValue{
+					{Key: "username", Value: variable("username"), Type: "string"},
+					{Key: "password", Value: variable("password"), Type: "string"},
+				}}, variables
+			case scheme.Type == "http":
+				return &Auth{Type: "bearer", Bearer: []KeyValue{{Key: "token", Value: variable("bearerToken"), Type: "string"}}}, variables
+			case scheme.Type == "apiKey":
+				in := scheme.In
+				if in != "query" {
+					in = "header"
+				}
+				return &Auth{Type: "apikey", APIKey: []KeyValue{
+					{Key: "key", Value: scheme.Name, Type: "string"},
+					{Key: "value", Value: variable("apiKey"), Type: "string"},
+					{Key: "in", Value: in, Type: "string"},
+				}}, variables
+			case scheme.Type == "oauth2" || scheme.Type == "openIdConnect":
+				oauth2 := []KeyValue{
+					{Key: "accessToken", Value: variable("accessToken"), Type: "string"},
+					{Key: "addTokenTo", Value: "header", Type: "string"},
+				}
+				if flows := scheme.OAuthFlows; flows != nil {
+					oauth2 = append(oauth2, oauthFlow(flows)...)
+				}
+				return &Auth{Type: "oauth2", OAuth2: oauth2}, variables
+			}
+		}
+	}
+	return nil, variables
+}
+
+// oauthFlow fills the postman token request from the first flow of the scheme
+func oauthFlow(flows *oas.SecuritySchemeOauthObject) []KeyValue {
+	var grantType string
+	var flow *oas.SecuritySchemeOauthFlowObject
+	switch {
+	case flows.AuthorizationCode != nil:
+		grantType, flow = "authorization_code", flows.AuthorizationCode
+	case flows.Implicit != nil:
+		grantType, flow = "implicit", flows.Implicit
+	case flows.ResourceOwnerPassword != nil:
+		grantType, flow = "password_credentials", flows.ResourceOwnerPassword
+	case flows.ClientCredentials != nil:
+		grantType, flow = "client_credentials", flows.ClientCredentials
+	default:
+		return nil
+	}
+	values := []KeyValue{{Key: "grant_type", Value: grantType, Type: "string"}}
+	if flow.AuthorizationUrl != "" {
+		values = append(values, KeyValue{Key: "authUrl", Value: flow.AuthorizationUrl, Type: "string"})
+	}
+	if flow.TokenUrl != "" {
+		values = append(values, KeyValue{Key: "accessTokenUrl", Value: flow.TokenUrl, Type: "string"})
+	}
+	scopes := make([]string, 0, len(flow.Scopes))
+	for scope := range flow.Scopes {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	if len(scopes) != 0 {
+		values = append(values, KeyValue{Key: "scope", Value: strings.Join(scopes, " "), Type: "string"})
+	}
+	return values
+}
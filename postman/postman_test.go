@@ -0,0 +1,70 @@
+package postman
+
+import (
+	"testing"
+
+	"github.com/iancoleman/orderedmap"
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func getPostmanOpenAPIObject() *oas.OpenAPIObject {
+	userProperties := orderedmap.New()
+	userProperties.Set("name", &oas.SchemaObject{Type: "string", Example: "gopher"})
+	return &oas.OpenAPIObject{
+		Version: oas.OpenAPIVersion,
+		Info:    oas.InfoObject{Title: "Users", Version: "1.0"},
+		Servers: []oas.ServerObject{{URL: "https://api.example.com/"}},
+		Paths: oas.PathsObject{
+			"/users/{id}": {Put: &oas.OperationObject{
+				Tags:    []string{"users"},
+				Summary: "Update a user",
+				Parameters: []oas.ParameterObject{
+					{Name: "id", In: "path", Required: true, Example: "42", Schema: &oas.SchemaObject{Type: "string"}},
+					{Name: "notify", In: "query", Example: true, Schema: &oas.SchemaObject{Type: "boolean"}},
+					{Ref: oas.ParameterRefPrefix + "Client-Version"},
+				},
+				RequestBody: &oas.RequestBodyObject{Content: map[string]*oas.MediaTypeObject{
+					oas.ContentTypeJson: {Schema: oas.SchemaObject{Ref: oas.SchemaRefPrefix + "User"}},
+				}},
+				Responses: oas.ResponsesObject{"204": {Description: "updated"}},
+			}},
+			"/live": {Get: &oas.OperationObject{Responses: oas.ResponsesObject{"200": {Description: "alive"}}}},
+		},
+		Components: oas.ComponentsObject{
+			Schemas:         map[string]*oas.SchemaObject{"User": {Type: "object", Properties: userProperties}},
+			Parameters:      map[string]*oas.ParameterObject{"Client-Version": {Name: "Client-Version", In: "header"}},
+			SecuritySchemes: map[string]*oas.SecuritySchemeObject{"ApiKey": {Type: "apiKey", In: "header", Name: "X-API-Key"}},
+		},
+		Security: []map[string][]string{{"ApiKey": {}}},
+	}
+}
+
+func Test_NewCollection(t *testing.T) {
+	collection := NewCollection(getPostmanOpenAPIObject())
+
+	assert.Equal(t, SchemaURL, collection.Info.Schema)
+	assert.Equal(t, []Variable{
+		{Key: "baseUrl", Value: "https://api.example.com", Type: "string"},
+		{Key: "apiKey", Value: "", Type: "string"},
+	}, collection.Variable)
+	assert.Equal(t, &Auth{Type: "apikey", APIKey: []KeyValue{
+		{Key: "key", Value: "X-API-Key", Type: "string"},
+		{Key: "value", Value: "{{apiKey}}", Type: "string"},
+		{Key: "in", Value: "header", Type: "string"},
+	}}, collection.Auth)
+
+	assert.Len(t, collection.Item, 2)
+	assert.Equal(t, "default", collection.Item[0].Name)
+	assert.Equal(t, "users", collection.Item[1].Name)
+	request := collection.Item[1].Item[0].Request
+	assert.Equal(t, "{{baseUrl}}/users/:id?notify=true", request.URL.Raw)
+	assert.Equal(t, []string{"users", ":id"}, request.URL.Path)
+	assert.Equal(t, []KeyValue{{Key: "id", Value: "42"}}, request.URL.Variable)
+	assert.Equal(t, []KeyValue{
+		{Key: "Client-Version", Disabled: true},
+		{Key: "Content-Type", Value: oas.ContentTypeJson},
+	}, request.Header)
+	assert.Equal(t, "raw", request.Body.Mode)
+	assert.JSONEq(t, `{"name": "gopher"}`, request.Body.Raw)
+}
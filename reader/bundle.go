@@ -0,0 +1,178 @@
+package reader
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	log "github.com/sirupsen/logrus"
+)
+
+var fileRefRegexp = regexp.MustCompile(`"\$ref":\s*"([^"#][^"]*)"`)
+
+type bundleRoot struct {
+	Paths      map[string]json.RawMessage            `json:"paths"`
+	Components map[string]map[string]json.RawMessage `json:"components"`
+}
+
+type fileRef struct {
+	Ref string `json:"$ref"`
+}
+
+type componentRef struct {
+	kind string
+	name string
+}
+
+// bundler loads the files of a tree written by the writer in output dir mode
+type bundler struct {
+	// components is the component every file holds, by absolute path
+	components map[string]componentRef
+	// loaded is the content of every component, by kind and name
+	loaded map[string]map[string]json.RawMessage
+}
+
+// Bundle reads a spec split into several files with relative references, eg. written with
+// --output-dir, and merges it back into one document with local references
+func Bundle(rootPath string) (oas.OpenAPIObject, error) {
+	log.Infof("Bundling open api object tree %s ...", rootPath)
+	rootDir := filepath.Dir(rootPath)
+	content, err := readJSON(rootPath)
+	if err != nil {
+		return oas.OpenAPIObject{}, err
+	}
+	document := map[string]json.RawMessage{}
+	var root bundleRoot
+	if err := json.Unmarshal(content, &document); err != nil {
+		return oas.OpenAPIObject{}, fmt.Errorf("Can not parse the file %s: %v", rootPath, err)
+	}
+	if err := json.Unmarshal(content, &root); err != nil {
+		return oas.OpenAPIObject{}, fmt.Errorf("Can not parse the file %s: %v", rootPath, err)
+	}
+
+	b := bundler{components: map[string]componentRef{}, loaded: map[string]map[string]json.RawMessage{}}
+	for kind, components := range root.Components {
+		for name, component := range components {
+			if file, ok := b.fileOf(rootDir, component); ok {
+				b.components[file] = componentRef{kind: kind, name: name}
+			}
+		}
+	}
+	for kind, components := range root.Components {
+		for name, component := range components {
+			if err := b.loadComponent(rootDir, kind, name, component); err != nil {
+				return oas.OpenAPIObject{}, err
+			}
+		}
+	}
+
+	paths := map[string]json.RawMessage{}
+	for path, item := range root.Paths {
+		dir := rootDir
+		if file, ok := b.fileOf(rootDir, item); ok {
+			if item, err = readJSON(file); err != nil {
+				return oas.OpenAPIObject{}, err
+			}
+			dir = filepath.Dir(file)
+		}
+		if paths[path], err = b.localRefs(dir, item); err != nil {
+			return oas.OpenAPIObject{}, err
+		}
+	}
+
+	if document["paths"], err = json.Marshal(paths); err != nil {
+		return oas.OpenAPIObject{}, err
+	}
+	if document["components"], err = json.Marshal(b.loaded); err != nil {
+		return oas.OpenAPIObject{}, err
+	}
+	bundled, err := json.Marshal(document)
+	if err != nil {
+		return oas.OpenAPIObject{}, err
+	}
+	var openApiObject oas.OpenAPIObject
+	if err := json.Unmarshal(bundled, &openApiObject); err != nil {
+		return oas.OpenAPIObject{}, fmt.Errorf("Can not parse the bundle of %s: %v", rootPath, err)
+	}
+	return openApiObject, nil
+}
+
+// fileOf returns the absolute path of the file a reference object points to
+func (b *bundler) fileOf(dir string, value json.RawMessage) (string, bool) {
+	var ref fileRef
+	if err := json.Unmarshal(value, &ref); err != nil || ref.Ref == "" || strings.HasPrefix(ref.Ref, "#") {
+		return "", false
+	}
+	file, err := filepath.Abs(filepath.Join(dir, filepath.FromSlash(strings.Split(ref.Ref, "#")[0])))
+	return file, err == nil
+}
+
+func (b *bundler) loadComponent(dir, kind, name string, component json.RawMessage) error {
+	if _, ok := b.loaded[kind][name]; ok {
+		return nil
+	}
+	if b.loaded[kind] == nil {
+		b.loaded[kind] = map[string]json.RawMessage{}
+	}
+	if file, ok := b.fileOf(dir, component); ok {
+		content, err := readJSON(file)
+		if err != nil {
+			return err
+		}
+		component, dir = content, filepath.Dir(file)
+	}
+	// registered before the references are followed, a component may refer to itself
+	b.loaded[kind][name] = component
+	component, err := b.localRefs(dir, component)
+	if err != nil {
+		return err
+	}
+	b.loaded[kind][name] = component
+	return nil
+}
+
+// localRefs rewrites the references to the component files into local references, loading the
+// components on the way
+func (b *bundler) localRefs(dir string, content json.RawMessage) (json.RawMessage, error) {
+	var err error
+	rewritten := fileRefRegexp.ReplaceAllFunc(content, func(match []byte) []byte {
+		ref := fileRefRegexp.FindSubmatch(match)[1]
+		file, absErr := filepath.Abs(filepath.Join(dir, filepath.FromSlash(strings.Split(string(ref), "#")[0])))
+		if absErr != nil {
+			err = absErr
+			return match
+		}
+		component, ok := b.components[file]
+		if !ok {
+			// a component missing from the root file is named after its file and directory
+			component = componentRef{kind: filepath.Base(filepath.Dir(file)), name: strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))}
+			b.components[file] = component
+		}
+		if loadErr := b.loadComponent(dir, component.kind, component.name, json.RawMessage(`{"$ref":`+jsonString(string(ref))+`}`)); loadErr != nil {
+			err = loadErr
+		}
+		return []byte(`"$ref":` + jsonString("#/components/"+component.kind+"/"+component.name))
+	})
+	return rewritten, err
+}
+
+func jsonString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+func readJSON(path string) (json.RawMessage, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Can not read the file %s: %v", path, err)
+	}
+	content, err = ToJSON(content)
+	if err != nil {
+		return nil, fmt.Errorf("Can not parse the file %s: %v", path, err)
+	}
+	return content, nil
+}
@@ -0,0 +1,114 @@
+package reader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+type Reader interface {
+	Read(path string) (oas.OpenAPIObject, error)
+}
+
+type fileReader struct{}
+
+func NewFileReader() *fileReader {
+	return &fileReader{}
+}
+
+// Read loads a json or yaml open api document, as written by the file writer
+func (r *fileReader) Read(path string) (oas.OpenAPIObject, error) {
+	log.Infof("Reading open api object file %s ...", path)
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return oas.OpenAPIObject{}, fmt.Errorf("Can not read the file %s: %v", path, err)
+	}
+	openApiObject, err := Unmarshal(content)
+	if err != nil {
+		return oas.OpenAPIObject{}, fmt.Errorf("Can not parse the file %s: %v", path, err)
+	}
+	return openApiObject, nil
+}
+
+// Unmarshal decodes a json or yaml open api document
+func Unmarshal(content []byte) (oas.OpenAPIObject, error) {
+	var openApiObject oas.OpenAPIObject
+	content, err := ToJSON(content)
+	if err != nil {
+		return openApiObject, err
+	}
+	err = json.Unmarshal(content, &openApiObject)
+	return openApiObject, err
+}
+
+// ToJSON converts a yaml document to json keeping the order of the mapping keys.
+// A json document is returned unchanged.
+func ToJSON(content []byte) ([]byte, error) {
+	trimmed := bytes.TrimSpace(content)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return content, nil
+	}
+	var document yaml.MapSlice
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+	return json.Marshal(yamlToJSONValue(document))
+}
+
+func yamlToJSONValue(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case yaml.MapSlice:
+		object := orderedObject{}
+		for _, item := range typedValue {
+			object = append(object, orderedField{Key: fmt.Sprint(item.Key), Value: yamlToJSONValue(item.Value)})
+		}
+		return object
+	case map[interface{}]interface{}:
+		object := map[string]interface{}{}
+		for key, item := range typedValue {
+			object[fmt.Sprint(key)] = yamlToJSONValue(item)
+		}
+		return object
+	case []interface{}:
+		for i := range typedValue {
+			typedValue[i] = yamlToJSONValue(typedValue[i])
+		}
+		return typedValue
+	}
+	return value
+}
+
+type orderedField struct {
+	Key   string
+	Value interface{}
+}
+
+type orderedObject []orderedField
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
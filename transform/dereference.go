@@ -0,0 +1,167 @@
+package transform
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/iancoleman/orderedmap"
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+)
+
+var schemaRefRegexp = regexp.MustCompile(`"\$ref":"#/components/schemas/([^"]+)"`)
+
+// dereferencer inlines the schema references
+type dereferencer struct {
+	openAPI *oas.OpenAPIObject
+	// maxDepth is how many times a recursive type is expanded inside itself, 0 leaves the recursive
+	// reference as it is
+	maxDepth int
+	// expanding counts the expansions of every component on the way to the current schema
+	expanding map[string]int
+}
+
+// Dereference returns a copy of the spec where the schema and parameter references of the operations
+// are replaced by the definitions they point to. A recursive type is expanded maxDepth times inside
+// itself and then cut to an empty object, with maxDepth 0 the recursive reference is kept along with
+// the components it needs. The components nothing references anymore are removed.
+func Dereference(openAPI *oas.OpenAPIObject, maxDepth int) (*oas.OpenAPIObject, error) {
+	dereferenced, err := clone(openAPI)
+	if err != nil {
+		return nil, err
+	}
+	d := dereferencer{openAPI: openAPI, maxDepth: maxDepth, expanding: map[string]int{}}
+
+	for _, item := range dereferenced.Paths {
+		for _, method := range oas.Methods {
+			op := item.Operation(method)
+			if op == nil {
+				continue
+			}
+			for i := range op.Parameters {
+				parameter := openAPI.ResolveParameter(&op.Parameters[i])
+				inlined := *parameter
+				inlined.Schema = d.schema(parameter.Schema)
+				op.Parameters[i] = inlined
+			}
+			if op.RequestBody != nil {
+				for _, mediaType := range op.RequestBody.Content {
+					if mediaType != nil {
+						mediaType.Schema = *d.schema(&mediaType.Schema)
+					}
+				}
+			}
+			for _, response := range op.Responses {
+				if response == nil {
+					continue
+				}
+				for _, mediaType := range response.Content {
+					if mediaType != nil {
+						mediaType.Schema = *d.schema(&mediaType.Schema)
+					}
+				}
+			}
+		}
+	}
+
+	// the components left are the ones the recursive references still need
+	dereferenced.Components.Parameters = nil
+	schemas := dereferenced.Components.Schemas
+	dereferenced.Components.Schemas = nil
+	b, err := json.Marshal(dereferenced.Paths)
+	if err != nil {
+		return nil, err
+	}
+	needed := referencedSchemas(b, map[string]bool{})
+	for len(needed) != 0 {
+		if dereferenced.Components.Schemas == nil {
+			dereferenced.Components.Schemas = map[string]*oas.SchemaObject{}
+		}
+		next := map[string]bool{}
+		for name := range needed {
+			if _, ok := dereferenced.Components.Schemas[name]; ok || schemas[name] == nil {
+				continue
+			}
+			d.expanding[name]++
+			component := d.schema(schemas[name])
+			d.expanding[name]--
+			dereferenced.Components.Schemas[name] = component
+			if b, err := json.Marshal(component); err == nil {
+				next = referencedSchemas(b, next)
+			}
+		}
+		for name := range next {
+			if _, ok := dereferenced.Components.Schemas[name]; ok {
+				delete(next, name)
+			}
+		}
+		needed = next
+	}
+	return dereferenced, nil
+}
+
+// schema returns a copy of the schema with its references inlined
+func (d dereferencer) schema(schema *oas.SchemaObject) *oas.SchemaObject {
+	if schema == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, oas.SchemaRefPrefix)
+		resolved, ok := d.openAPI.SchemaByRef(schema.Ref)
+		if !ok {
+			return schema
+		}
+		if d.expanding[name] != 0 {
+			if d.maxDepth == 0 {
+				return schema
+			}
+			if d.expanding[name] > d.maxDepth {
+				return &oas.SchemaObject{Type: "object", Description: "recursive " + name}
+			}
+		}
+		d.expanding[name]++
+		defer func() { d.expanding[name]-- }()
+		inlined := d.schema(resolved)
+		if schema.Description != "" {
+			inlined.Description = schema.Description
+		}
+		inlined.Nullable = inlined.Nullable || schema.Nullable
+		return inlined
+	}
+
+	inlined := *schema
+	inlined.Items = d.schema(schema.Items)
+	inlined.Not = d.schema(schema.Not)
+	if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+		inlined.AdditionalProperties = oas.AdditionalPropertiesOf(d.schema(schema.AdditionalProperties.Schema))
+	}
+	if schema.Properties != nil {
+		inlined.Properties = orderedmap.New()
+		for _, name := range schema.Properties.Keys() {
+			if property, ok := schema.PropertySchema(name); ok {
+				inlined.Properties.Set(name, d.schema(property))
+			}
+		}
+	}
+	return &inlined
+}
+
+// referencedSchemas adds the components the json of a part of the spec references to names
+func referencedSchemas(b []byte, names map[string]bool) map[string]bool {
+	for _, match := range schemaRefRegexp.FindAllSubmatch(b, -1) {
+		names[string(match[1])] = true
+	}
+	return names
+}
+
+func clone(openAPI *oas.OpenAPIObject) (*oas.OpenAPIObject, error) {
+	b, err := json.Marshal(openAPI)
+	if err != nil {
+		return nil, err
+	}
+	cloned := &oas.OpenAPIObject{}
+	if err := json.Unmarshal(b, cloned); err != nil {
+		return nil, err
+	}
+	return cloned, nil
+}
@@ -0,0 +1,75 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/iancoleman/orderedmap"
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTransformOpenAPIObject() *oas.OpenAPIObject {
+	nodeProperties := orderedmap.New()
+	nodeProperties.Set("name", &oas.SchemaObject{Type: "string"})
+	nodeProperties.Set("children", &oas.SchemaObject{Type: "array", Items: &oas.SchemaObject{Ref: oas.SchemaRefPrefix + "Node"}})
+	treeProperties := orderedmap.New()
+	treeProperties.Set("root", &oas.SchemaObject{Ref: oas.SchemaRefPrefix + "Node"})
+	return &oas.OpenAPIObject{
+		Paths: oas.PathsObject{
+			"/trees/{id}": {Get: &oas.OperationObject{
+				Parameters: []oas.ParameterObject{
+					{Name: "id", In: "path", Required: true, Schema: &oas.SchemaObject{Type: "string"}},
+					{Ref: oas.ParameterRefPrefix + "Version"},
+				},
+				Responses: oas.ResponsesObject{"200": {Description: "the tree", Content: map[string]*oas.MediaTypeObject{
+					oas.ContentTypeJson: {Schema: oas.SchemaObject{Ref: oas.SchemaRefPrefix + "Tree"}},
+				}}},
+			}},
+		},
+		Components: oas.ComponentsObject{
+			Schemas: map[string]*oas.SchemaObject{
+				"Tree":   {Type: "object", Properties: treeProperties},
+				"Node":   {Type: "object", Properties: nodeProperties},
+				"Unused": {Type: "string"},
+			},
+			Parameters: map[string]*oas.ParameterObject{
+				"Version": {Name: "Version", In: "header", Schema: &oas.SchemaObject{Type: "string"}},
+			},
+		},
+	}
+}
+
+func Test_Dereference_KeepsRecursiveReferences(t *testing.T) {
+	openAPI := getTransformOpenAPIObject()
+
+	dereferenced, err := Dereference(openAPI, 0)
+
+	assert.NoError(t, err)
+	op := dereferenced.Paths["/trees/{id}"].Get
+	assert.Equal(t, "Version", op.Parameters[1].Name)
+	assert.Equal(t, "header", op.Parameters[1].In)
+	tree := op.Responses["200"].Content[oas.ContentTypeJson].Schema
+	assert.Empty(t, tree.Ref)
+	root, _ := tree.PropertySchema("root")
+	assert.Empty(t, root.Ref)
+	children, _ := root.PropertySchema("children")
+	assert.Equal(t, oas.SchemaRefPrefix+"Node", children.Items.Ref)
+	assert.Len(t, dereferenced.Components.Schemas, 1)
+	assert.Contains(t, dereferenced.Components.Schemas, "Node")
+	assert.Empty(t, dereferenced.Components.Parameters)
+	assert.Equal(t, oas.SchemaRefPrefix+"Tree", openAPI.Paths["/trees/{id}"].Get.Responses["200"].Content[oas.ContentTypeJson].Schema.Ref, "the spec is left unchanged")
+}
+
+func Test_Dereference_CutsRecursiveTypes(t *testing.T) {
+	dereferenced, err := Dereference(getTransformOpenAPIObject(), 1)
+
+	assert.NoError(t, err)
+	tree := dereferenced.Paths["/trees/{id}"].Get.Responses["200"].Content[oas.ContentTypeJson].Schema
+	root, _ := tree.PropertySchema("root")
+	children, _ := root.PropertySchema("children")
+	child := children.Items
+	assert.Empty(t, child.Ref)
+	grandChildren, _ := child.PropertySchema("children")
+	assert.Equal(t, &oas.SchemaObject{Type: "object", Description: "recursive Node"}, grandChildren.Items)
+	assert.Empty(t, dereferenced.Components.Schemas)
+}
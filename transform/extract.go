@@ -0,0 +1,219 @@
+package transform
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+)
+
+// HoistInlineSchemas returns a copy of the spec where the inline objects of the schemas, params,
+// bodies and responses are component schemas, so that they are declared as go types the
+// annotations can name. Inline enum params become components too, the parser only references
+// them. An inline schema identical to a component references it.
+func HoistInlineSchemas(openAPI *oas.OpenAPIObject) (*oas.OpenAPIObject, error) {
+	hoisted, err := copyWithSchemas(openAPI)
+	if err != nil {
+		return nil, err
+	}
+	h := hoister{openAPI: hoisted, components: ComponentsByJSON(hoisted), enums: true}
+	h.hoistAll()
+	return hoisted, nil
+}
+
+// ExtractInlineSchemas returns a copy of the spec where the inline objects found more than once
+// reference a new component schema, and the ones identical to a component reference it. The other
+// inline objects are left as they are.
+func ExtractInlineSchemas(openAPI *oas.OpenAPIObject) (*oas.OpenAPIObject, error) {
+	counted, err := copyWithSchemas(openAPI)
+	if err != nil {
+		return nil, err
+	}
+	counts := map[string]int{}
+	counter := hoister{
+		openAPI:    counted,
+		components: map[string]string{},
+		only:       func([]byte) bool { return false },
+		seen:       func(b []byte) { counts[string(b)]++ },
+	}
+	counter.hoistAll()
+
+	extracted, err := copyWithSchemas(openAPI)
+	if err != nil {
+		return nil, err
+	}
+	h := hoister{openAPI: extracted, components: ComponentsByJSON(extracted), only: func(b []byte) bool { return counts[string(b)] > 1 }}
+	h.hoistAll()
+	return extracted, nil
+}
+
+// copyWithSchemas clones a spec, with a map the hoisted schemas can be added to
+func copyWithSchemas(openAPI *oas.OpenAPIObject) (*oas.OpenAPIObject, error) {
+	copied, err := clone(openAPI)
+	if err != nil {
+		return nil, err
+	}
+	if copied.Components.Schemas == nil {
+		copied.Components.Schemas = map[string]*oas.SchemaObject{}
+	}
+	return copied, nil
+}
+
+type hoister struct {
+	openAPI *oas.OpenAPIObject
+	// components is the key of every component schema by its json
+	components map[string]string
+	// enums hoists the inline enum params
+	enums bool
+	// only tells which inline objects to hoist by their json, all of them when nil
+	only func(b []byte) bool
+	// seen is called with the json of every inline object met
+	seen func(b []byte)
+}
+
+// hoistAll hoists the inline objects of the component schemas and of the operations
+func (h hoister) hoistAll() {
+	keys := make([]string, 0, len(h.openAPI.Components.Schemas))
+	for key := range h.openAPI.Components.Schemas {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		schema := h.openAPI.Components.Schemas[key]
+		h.hoistProperties(schema, GoName(LastSegment(key)))
+		if schema.Type == "array" {
+			h.hoist(schema.Items, GoName(LastSegment(key))+"Item")
+		}
+	}
+
+	for _, operation := range Operations(h.openAPI) {
+		op := operation.Operation
+		for i := range op.Parameters {
+			parameter := &op.Parameters[i]
+			if h.enums && IsEnumSchema(parameter.Schema) {
+				h.replace(parameter.Schema, operation.Name+GoName(parameter.Name)+"Enum")
+				continue
+			}
+			h.hoist(parameter.Schema, operation.Name+GoName(parameter.Name))
+		}
+		if op.RequestBody != nil {
+			if mediaType, ok := op.RequestBody.Content[oas.ContentTypeJson]; ok {
+				h.hoist(&mediaType.Schema, operation.Name+"Request")
+			}
+		}
+		statuses := make([]string, 0, len(op.Responses))
+		for status := range op.Responses {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+		for i, status := range statuses {
+			mediaType, ok := op.Responses[status].Content[oas.ContentTypeJson]
+			if !ok {
+				continue
+			}
+			name := operation.Name + "Response"
+			if i != 0 || status[0] != '2' {
+				name = operation.Name + strings.Title(status) + "Response"
+			}
+			h.hoist(&mediaType.Schema, name)
+		}
+	}
+}
+
+// hoist replaces an inline object by a reference to a component, for arrays it hoists the items
+func (h hoister) hoist(schema *oas.SchemaObject, name string) {
+	if schema == nil || schema.Ref != "" {
+		return
+	}
+	if schema.Type == "array" {
+		h.hoist(schema.Items, name+"Item")
+		return
+	}
+	if schema.AdditionalProperties != nil {
+		h.hoist(schema.AdditionalProperties.Schema, name+"Value")
+	}
+	if schema.Properties == nil || len(schema.Properties.Keys()) == 0 {
+		return
+	}
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return
+	}
+	if h.seen != nil {
+		h.seen(b)
+	}
+	if _, ok := h.components[string(b)]; ok || h.only == nil || h.only(b) {
+		h.replace(schema, name)
+		return
+	}
+	h.hoistProperties(schema, name)
+}
+
+func (h hoister) replace(schema *oas.SchemaObject, name string) {
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return
+	}
+	key, ok := h.components[string(b)]
+	if !ok {
+		h.hoistProperties(schema, name)
+		key = h.unusedKey(name)
+		component := *schema
+		h.openAPI.Components.Schemas[key] = &component
+		h.components[string(b)] = key
+	}
+	*schema = oas.SchemaObject{Ref: oas.SchemaRefPrefix + key}
+}
+
+func (h hoister) hoistProperties(schema *oas.SchemaObject, name string) {
+	if schema == nil || schema.Properties == nil {
+		return
+	}
+	for _, jsonName := range schema.Properties.Keys() {
+		if property, ok := schema.PropertySchema(jsonName); ok {
+			h.hoist(property, name+GoName(jsonName))
+		}
+	}
+}
+
+func (h hoister) unusedKey(name string) string {
+	used := map[string]bool{}
+	for _, typeName := range ComponentTypeNames(h.openAPI) {
+		used[typeName] = true
+	}
+	key := name
+	for i := 2; used[key]; i++ {
+		key = name + strconv.Itoa(i)
+	}
+	return key
+}
+
+// IsEnumSchema tells if a schema is a basic type restricted to a list of values, which the
+// parser builds from an @Enum struct
+func IsEnumSchema(schema *oas.SchemaObject) bool {
+	if schema == nil || schema.Ref != "" || schema.Properties != nil {
+		return false
+	}
+	enum, ok := schema.Enum.([]interface{})
+	return ok && len(enum) != 0
+}
+
+// ComponentsByJSON is the key of every component schema by its json, the first key in order for
+// identical schemas
+func ComponentsByJSON(openAPI *oas.OpenAPIObject) map[string]string {
+	keys := make([]string, 0, len(openAPI.Components.Schemas))
+	for key := range openAPI.Components.Schemas {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	components := map[string]string{}
+	for _, key := range keys {
+		b, err := json.Marshal(openAPI.Components.Schemas[key])
+		if _, ok := components[string(b)]; err == nil && !ok {
+			components[string(b)] = key
+		}
+	}
+	return components
+}
@@ -0,0 +1,91 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/iancoleman/orderedmap"
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func getExtractOpenAPIObject() *oas.OpenAPIObject {
+	userProperties := orderedmap.New()
+	userProperties.Set("id", &oas.SchemaObject{Type: "integer"})
+	userProperties.Set("name", &oas.SchemaObject{Type: "string"})
+	idParameter := oas.ParameterObject{Name: "id", In: "path", Required: true, Schema: &oas.SchemaObject{Type: "integer"}}
+	return &oas.OpenAPIObject{
+		Paths: oas.PathsObject{
+			"/users/{id}": {
+				Get: &oas.OperationObject{
+					OperationID: "GetUser",
+					Parameters:  []oas.ParameterObject{idParameter},
+					Responses: oas.ResponsesObject{
+						"200": {Content: map[string]*oas.MediaTypeObject{
+							oas.ContentTypeJson: {Schema: oas.SchemaObject{Ref: "#/components/schemas/model.User"}},
+						}},
+					},
+				},
+				Delete: &oas.OperationObject{
+					Parameters: []oas.ParameterObject{idParameter},
+					Responses:  oas.ResponsesObject{"204": {Description: "Deleted"}},
+				},
+			},
+		},
+		Components: oas.ComponentsObject{
+			Schemas: map[string]*oas.SchemaObject{
+				"model.User": {Type: "object", Properties: userProperties, Required: []string{"id"}},
+			},
+		},
+	}
+}
+
+func Test_HoistInlineSchemas(t *testing.T) {
+	openAPI := getExtractOpenAPIObject()
+	userProperties := orderedmap.New()
+	userProperties.Set("id", &oas.SchemaObject{Type: "integer"})
+	userProperties.Set("name", &oas.SchemaObject{Type: "string"})
+	messageProperties := orderedmap.New()
+	messageProperties.Set("message", &oas.SchemaObject{Type: "string"})
+	operation := openAPI.Paths["/users/{id}"].Get
+	operation.Responses["200"].Content[oas.ContentTypeJson].Schema = oas.SchemaObject{Type: "object", Properties: userProperties, Required: []string{"id"}}
+	operation.Responses["500"] = &oas.ResponseObject{Content: map[string]*oas.MediaTypeObject{
+		oas.ContentTypeJson: {Schema: oas.SchemaObject{Type: "array", Items: &oas.SchemaObject{Type: "object", Properties: messageProperties}}},
+	}}
+
+	hoisted, err := HoistInlineSchemas(openAPI)
+
+	assert.NoError(t, err)
+	responses := hoisted.Paths["/users/{id}"].Get.Responses
+	assert.Equal(t, "#/components/schemas/model.User", responses["200"].Content[oas.ContentTypeJson].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/GetUser500ResponseItem", responses["500"].Content[oas.ContentTypeJson].Schema.Items.Ref)
+	assert.Contains(t, hoisted.Components.Schemas, "GetUser500ResponseItem")
+	assert.Empty(t, operation.Responses["200"].Content[oas.ContentTypeJson].Schema.Ref, "the spec is left unchanged")
+}
+
+func Test_ExtractInlineSchemas(t *testing.T) {
+	openAPI := getExtractOpenAPIObject()
+	messageProperties := orderedmap.New()
+	messageProperties.Set("message", &oas.SchemaObject{Type: "string"})
+	reasonProperties := orderedmap.New()
+	reasonProperties.Set("reason", &oas.SchemaObject{Type: "string"})
+	message := func() *oas.ResponseObject {
+		return &oas.ResponseObject{Content: map[string]*oas.MediaTypeObject{
+			oas.ContentTypeJson: {Schema: oas.SchemaObject{Type: "object", Properties: messageProperties}},
+		}}
+	}
+	paths := openAPI.Paths["/users/{id}"]
+	paths.Get.Responses["500"] = message()
+	paths.Get.Responses["400"] = &oas.ResponseObject{Content: map[string]*oas.MediaTypeObject{
+		oas.ContentTypeJson: {Schema: oas.SchemaObject{Type: "object", Properties: reasonProperties}},
+	}}
+	paths.Delete.Responses["500"] = message()
+
+	extracted, err := ExtractInlineSchemas(openAPI)
+
+	assert.NoError(t, err)
+	get, del := extracted.Paths["/users/{id}"].Get, extracted.Paths["/users/{id}"].Delete
+	assert.Equal(t, "#/components/schemas/GetUser500Response", get.Responses["500"].Content[oas.ContentTypeJson].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/GetUser500Response", del.Responses["500"].Content[oas.ContentTypeJson].Schema.Ref)
+	assert.Empty(t, get.Responses["400"].Content[oas.ContentTypeJson].Schema.Ref, "an inline object found once is left inline")
+	assert.Len(t, extracted.Components.Schemas, 2)
+}
@@ -0,0 +1,142 @@
+package transform
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+)
+
+var commonInitialisms = map[string]bool{
+	"API": true, "ID": true, "IDS": true, "URL": true, "URI": true, "HTTP": true, "HTTPS": true,
+	"JSON": true, "XML": true, "UUID": true, "IP": true, "SQL": true, "TTL": true, "UI": true,
+}
+
+// GoName turns a json name, a path or an operation id into an exported go identifier,
+// eg. "user_id" -> "UserID", "Client-Version" -> "ClientVersion"
+func GoName(name string) string {
+	words := splitWords(name)
+	var builder strings.Builder
+	for _, word := range words {
+		upper := strings.ToUpper(word)
+		if commonInitialisms[upper] {
+			if upper == "IDS" {
+				upper = "IDs"
+			}
+			builder.WriteString(upper)
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		builder.WriteString(string(runes))
+	}
+	goName := builder.String()
+	if goName == "" {
+		return "X"
+	}
+	if unicode.IsDigit([]rune(goName)[0]) {
+		return "X" + goName
+	}
+	return goName
+}
+
+// splitWords splits on every non alphanumeric character and on lower to upper case changes
+func splitWords(name string) []string {
+	var words []string
+	var current []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(current) != 0 {
+				words = append(words, string(current))
+				current = nil
+			}
+			continue
+		}
+		if len(current) != 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])) {
+			words = append(words, string(current))
+			current = nil
+		}
+		current = append(current, r)
+	}
+	if len(current) != 0 {
+		words = append(words, string(current))
+	}
+	return words
+}
+
+// ComponentTypeNames maps every component schema to the go type generated for it. The type name
+// is the last segment of the schema ID, prefixed with the package when two packages share a type name.
+func ComponentTypeNames(openAPI *oas.OpenAPIObject) map[string]string {
+	keys := make([]string, 0, len(openAPI.Components.Schemas))
+	for key := range openAPI.Components.Schemas {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	byTypeName := map[string][]string{}
+	for _, key := range keys {
+		typeName := GoName(LastSegment(key))
+		byTypeName[typeName] = append(byTypeName[typeName], key)
+	}
+
+	names := map[string]string{}
+	for typeName, componentKeys := range byTypeName {
+		if len(componentKeys) == 1 {
+			names[componentKeys[0]] = typeName
+			continue
+		}
+		for _, key := range componentKeys {
+			names[key] = GoName(strings.ReplaceAll(key, "/", "."))
+		}
+	}
+	return names
+}
+
+// LastSegment is the name of a component schema without its package, eg. User for model.User
+func LastSegment(key string) string {
+	key = strings.ReplaceAll(key, "/", ".")
+	parts := strings.Split(key, ".")
+	return parts[len(parts)-1]
+}
+
+// Operation is an operation of the spec together with its route and a unique go name
+type Operation struct {
+	Name      string
+	Method    string
+	Path      string
+	Operation *oas.OperationObject
+}
+
+// Operations lists the operations of the spec sorted by path and method. The go name is the
+// operation id, or the method followed by the path when the operation has no id.
+func Operations(openAPI *oas.OpenAPIObject) []Operation {
+	paths := make([]string, 0, len(openAPI.Paths))
+	for path := range openAPI.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var operations []Operation
+	usedNames := map[string]int{}
+	for _, path := range paths {
+		for _, method := range oas.Methods {
+			operation := openAPI.Paths[path].Operation(method)
+			if operation == nil {
+				continue
+			}
+			name := GoName(operation.OperationID)
+			if operation.OperationID == "" {
+				name = GoName(strings.ToLower(method) + " " + strings.NewReplacer("{", " by ", "}", " ").Replace(path))
+			}
+			usedNames[name]++
+			if usedNames[name] > 1 {
+				name = name + strconv.Itoa(usedNames[name])
+			}
+			operations = append(operations, Operation{Name: name, Method: method, Path: path, Operation: operation})
+		}
+	}
+	return operations
+}
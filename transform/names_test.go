@@ -0,0 +1,21 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GoName(t *testing.T) {
+	tests := map[string]string{
+		"user_id":        "UserID",
+		"Client-Version": "ClientVersion",
+		"extra.field":    "ExtraField",
+		"GetRestaurants": "GetRestaurants",
+		"getUserByID":    "GetUserByID",
+		"200":            "X200",
+	}
+	for name, expected := range tests {
+		assert.Equal(t, expected, GoName(name), name)
+	}
+}
@@ -0,0 +1,122 @@
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+)
+
+var parameterRefRegexp = regexp.MustCompile(`"\$ref":"#/components/parameters/([^"]+)"`)
+
+const (
+	OrphanSchema         = "schema"
+	OrphanParameter      = "parameter"
+	OrphanSecurityScheme = "security scheme"
+)
+
+// Orphan is a component no operation reaches
+type Orphan struct {
+	Kind string
+	Name string
+}
+
+func (o Orphan) String() string {
+	return fmt.Sprintf("%s %s", o.Kind, o.Name)
+}
+
+// Orphans lists the components the paths do not reach, neither directly nor through other
+// components. The security schemes are reached by the security requirements.
+func Orphans(openAPI *oas.OpenAPIObject) ([]Orphan, error) {
+	b, err := json.Marshal(openAPI.Paths)
+	if err != nil {
+		return nil, err
+	}
+	parameters := map[string]bool{}
+	for _, match := range parameterRefRegexp.FindAllSubmatch(b, -1) {
+		parameters[string(match[1])] = true
+	}
+	for name := range parameters {
+		if parameter, ok := openAPI.Components.Parameters[name]; ok {
+			parameterJSON, err := json.Marshal(parameter)
+			if err != nil {
+				return nil, err
+			}
+			b = append(b, parameterJSON...)
+		}
+	}
+
+	schemas := referencedSchemas(b, map[string]bool{})
+	for needed := schemas; len(needed) != 0; {
+		next := map[string]bool{}
+		for name := range needed {
+			schema, ok := openAPI.Components.Schemas[name]
+			if !ok {
+				continue
+			}
+			schemaJSON, err := json.Marshal(schema)
+			if err != nil {
+				return nil, err
+			}
+			for ref := range referencedSchemas(schemaJSON, map[string]bool{}) {
+				if !schemas[ref] {
+					schemas[ref] = true
+					next[ref] = true
+				}
+			}
+		}
+		needed = next
+	}
+
+	securitySchemes := map[string]bool{}
+	for _, requirement := range openAPI.Security {
+		for name := range requirement {
+			securitySchemes[name] = true
+		}
+	}
+
+	var orphans []Orphan
+	for name := range openAPI.Components.Schemas {
+		if !schemas[name] {
+			orphans = append(orphans, Orphan{Kind: OrphanSchema, Name: name})
+		}
+	}
+	for name := range openAPI.Components.Parameters {
+		if !parameters[name] {
+			orphans = append(orphans, Orphan{Kind: OrphanParameter, Name: name})
+		}
+	}
+	for name := range openAPI.Components.SecuritySchemes {
+		if !securitySchemes[name] {
+			orphans = append(orphans, Orphan{Kind: OrphanSecurityScheme, Name: name})
+		}
+	}
+	sort.Slice(orphans, func(i, j int) bool {
+		if orphans[i].Kind != orphans[j].Kind {
+			return orphans[i].Kind < orphans[j].Kind
+		}
+		return orphans[i].Name < orphans[j].Name
+	})
+	return orphans, nil
+}
+
+// Prune removes the orphan components from the spec and returns them
+func Prune(openAPI *oas.OpenAPIObject) ([]Orphan, error) {
+	orphans, err := Orphans(openAPI)
+	if err != nil {
+		return nil, err
+	}
+	for _, orphan := range orphans {
+		switch orphan.Kind {
+		case OrphanSchema:
+			delete(openAPI.Components.Schemas, orphan.Name)
+		case OrphanParameter:
+			delete(openAPI.Components.Parameters, orphan.Name)
+		case OrphanSecurityScheme:
+			delete(openAPI.Components.SecuritySchemes, orphan.Name)
+		}
+	}
+	return orphans, nil
+}
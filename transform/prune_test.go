@@ -0,0 +1,30 @@
+package transform
+
+import (
+	"testing"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Prune(t *testing.T) {
+	openAPI := getTransformOpenAPIObject()
+	openAPI.Components.Parameters["Unused"] = &oas.ParameterObject{Name: "Unused", In: "header", Schema: &oas.SchemaObject{Ref: oas.SchemaRefPrefix + "Unused"}}
+	openAPI.Components.SecuritySchemes = map[string]*oas.SecuritySchemeObject{
+		"ApiKey": {Type: "apiKey", In: "header", Name: "X-API-Key"},
+		"Basic":  {Type: "http", Scheme: "basic"},
+	}
+	openAPI.Security = []map[string][]string{{"ApiKey": {}}}
+
+	orphans, err := Prune(openAPI)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Orphan{
+		{Kind: OrphanParameter, Name: "Unused"},
+		{Kind: OrphanSchema, Name: "Unused"},
+		{Kind: OrphanSecurityScheme, Name: "Basic"},
+	}, orphans)
+	assert.Len(t, openAPI.Components.Schemas, 2, "the schemas reached through other schemas are kept")
+	assert.Contains(t, openAPI.Components.Parameters, "Version")
+	assert.Len(t, openAPI.Components.SecuritySchemes, 1)
+}
@@ -0,0 +1,185 @@
+package validator
+
+import (
+	"bytes"
+	"math/rand"
+	"net/http"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/reader"
+	log "github.com/sirupsen/logrus"
+)
+
+type Mode int
+
+const (
+	// ModeReject answers invalid requests with a 400 and replaces invalid responses with a 500
+	ModeReject Mode = iota
+	// ModeLogOnly reports every mismatch and lets the traffic through untouched
+	ModeLogOnly
+	// ModeSampled behaves like ModeLogOnly for a random share of the requests, see Options.SampleRate
+	ModeSampled
+)
+
+// DefaultSampleRate is the share of requests validated in ModeSampled when Options.SampleRate is not set
+const DefaultSampleRate = 0.1
+
+// Mismatch is reported for every request or response that does not follow the spec
+type Mismatch struct {
+	Request *http.Request
+	Route   *Route // nil when the request path or method is not documented
+	// Response is false for request mismatches
+	Response   bool
+	StatusCode int
+	Err        error
+}
+
+type Options struct {
+	Mode Mode
+	// SampleRate is the share of requests validated in ModeSampled, between 0 and 1, DefaultSampleRate
+	// when it is not set
+	SampleRate float64
+	// SkipResponses disables the validation of the responses
+	SkipResponses bool
+	// OnMismatch is called for every mismatch, mismatches are logged when it is nil
+	OnMismatch func(mismatch *Mismatch)
+}
+
+type middleware struct {
+	options   Options
+	router    *Router
+	validator *Validator
+	next      http.Handler
+}
+
+// NewMiddleware returns a middleware enforcing the spec on the wrapped handler
+func NewMiddleware(openAPI *oas.OpenAPIObject, options Options) func(http.Handler) http.Handler {
+	if options.OnMismatch == nil {
+		options.OnMismatch = logMismatch
+	}
+	if options.Mode == ModeSampled && options.SampleRate <= 0 {
+		options.SampleRate = DefaultSampleRate
+	}
+	router := NewRouter(openAPI)
+	validator := New(openAPI)
+	return func(next http.Handler) http.Handler {
+		return &middleware{options: options, router: router, validator: validator, next: next}
+	}
+}
+
+// NewMiddlewareFromFile loads a json or yaml spec generated by go-swagger3 and returns the middleware enforcing it
+func NewMiddlewareFromFile(path string, options Options) (func(http.Handler) http.Handler, error) {
+	openAPI, err := reader.NewFileReader().Read(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewMiddleware(&openAPI, options), nil
+}
+
+func (m *middleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if m.options.Mode == ModeSampled && rand.Float64() >= m.options.SampleRate {
+		m.next.ServeHTTP(w, r)
+		return
+	}
+	reject := m.options.Mode == ModeReject
+
+	route, err := m.router.FindRoute(r.Method, r.URL.Path)
+	if err != nil {
+		m.options.OnMismatch(&Mismatch{Request: r, Err: err})
+		if reject {
+			status := http.StatusNotFound
+			if err == ErrMethodNotAllowed {
+				status = http.StatusMethodNotAllowed
+			}
+			WriteErrors(w, status, err)
+			return
+		}
+		m.next.ServeHTTP(w, r)
+		return
+	}
+
+	if err := m.validator.ValidateRequest(r, route); err != nil {
+		m.options.OnMismatch(&Mismatch{Request: r, Route: route, Err: err})
+		if reject {
+			WriteErrors(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	if m.options.SkipResponses {
+		m.next.ServeHTTP(w, r)
+		return
+	}
+
+	recorder := &responseRecorder{ResponseWriter: w, buffered: reject, statusCode: http.StatusOK}
+	if reject {
+		recorder.header = http.Header{}
+	}
+	m.next.ServeHTTP(recorder, r)
+	if err := m.validator.ValidateResponse(route, recorder.statusCode, recorder.Header(), recorder.body.Bytes()); err != nil {
+		m.options.OnMismatch(&Mismatch{Request: r, Route: route, Response: true, StatusCode: recorder.statusCode, Err: err})
+		if reject {
+			WriteErrors(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	recorder.flush()
+}
+
+func logMismatch(mismatch *Mismatch) {
+	kind := "request"
+	if mismatch.Response {
+		kind = "response"
+	}
+	log.Warnf("spec mismatch in %s of %s %s: %s", kind, mismatch.Request.Method, mismatch.Request.URL.Path, mismatch.Err)
+}
+
+// responseRecorder keeps a copy of the response body. A buffered recorder holds the response back,
+// its headers included, until flush so that an invalid response can still be replaced.
+type responseRecorder struct {
+	http.ResponseWriter
+	buffered    bool
+	header      http.Header
+	wroteHeader bool
+	statusCode  int
+	body        bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	if r.buffered {
+		return r.header
+	}
+	return r.ResponseWriter.Header()
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	if r.wroteHeader {
+		return
+	}
+	r.wroteHeader = true
+	r.statusCode = statusCode
+	if !r.buffered {
+		r.ResponseWriter.WriteHeader(statusCode)
+	}
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	r.body.Write(b)
+	if r.buffered {
+		return len(b), nil
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) flush() {
+	if !r.buffered {
+		return
+	}
+	for key, values := range r.header {
+		r.ResponseWriter.Header()[key] = values
+	}
+	if r.wroteHeader {
+		r.ResponseWriter.WriteHeader(r.statusCode)
+	}
+	_, _ = r.ResponseWriter.Write(r.body.Bytes())
+}
@@ -0,0 +1,176 @@
+package validator
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const specYaml = `
+openapi: 3.0.0
+info:
+  title: Users
+  version: "1.0"
+paths:
+  /users/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: User
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+components:
+  schemas:
+    User:
+      type: object
+      required:
+        - name
+      properties:
+        name:
+          type: string
+        age:
+          type: integer
+          minimum: 18
+        scores:
+          type: object
+          additionalProperties:
+            type: integer
+`
+
+func Test_Middleware(t *testing.T) {
+	tests := []struct {
+		name               string
+		mode               Mode
+		sampleRate         float64
+		target             string
+		handlerStatus      int
+		handlerBody        string
+		expectedStatus     int
+		expectedBody       string
+		expectedMismatches int
+	}{
+		{
+			name:           "Should pass valid request and response through",
+			mode:           ModeReject,
+			target:         "/users/1",
+			handlerStatus:  http.StatusOK,
+			handlerBody:    `{"name":"Parvez","age":20}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"name":"Parvez","age":20}`,
+		},
+		{
+			name:               "Should reject invalid request",
+			mode:               ModeReject,
+			target:             "/users/one",
+			expectedStatus:     http.StatusBadRequest,
+			expectedBody:       `{"errors":["path id: expected integer but got \"one\""]}`,
+			expectedMismatches: 1,
+		},
+		{
+			name:               "Should replace invalid response",
+			mode:               ModeReject,
+			target:             "/users/1",
+			handlerStatus:      http.StatusOK,
+			handlerBody:        `{"age":10}`,
+			expectedStatus:     http.StatusInternalServerError,
+			expectedBody:       `{"errors":["response name: is required","response age: must be greater than or equal to 18"]}`,
+			expectedMismatches: 1,
+		},
+		{
+			name:               "Should validate the additional properties",
+			mode:               ModeReject,
+			target:             "/users/1",
+			handlerStatus:      http.StatusOK,
+			handlerBody:        `{"name":"Parvez","scores":{"go":1,"sql":"high"}}`,
+			expectedStatus:     http.StatusInternalServerError,
+			expectedBody:       `{"errors":["response scores.sql: expected integer but got string"]}`,
+			expectedMismatches: 1,
+		},
+		{
+			name:               "Should replace response with undocumented status",
+			mode:               ModeReject,
+			target:             "/users/1",
+			handlerStatus:      http.StatusNotFound,
+			handlerBody:        `{}`,
+			expectedStatus:     http.StatusInternalServerError,
+			expectedBody:       `{"errors":["response: status 404 is not documented"]}`,
+			expectedMismatches: 1,
+		},
+		{
+			name:               "Should only report mismatches in log only mode",
+			mode:               ModeLogOnly,
+			target:             "/users/one",
+			handlerStatus:      http.StatusOK,
+			handlerBody:        `{"age":10}`,
+			expectedStatus:     http.StatusOK,
+			expectedBody:       `{"age":10}`,
+			expectedMismatches: 2,
+		},
+		{
+			name:               "Should only report mismatches of the sampled requests",
+			mode:               ModeSampled,
+			sampleRate:         1,
+			target:             "/undocumented",
+			handlerStatus:      http.StatusOK,
+			handlerBody:        `{}`,
+			expectedStatus:     http.StatusOK,
+			expectedBody:       `{}`,
+			expectedMismatches: 1,
+		},
+	}
+	specPath := filepath.Join(t.TempDir(), "oas.yml")
+	assert.NoError(t, ioutil.WriteFile(specPath, []byte(specYaml), os.ModePerm))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mismatches := 0
+			middleware, err := NewMiddlewareFromFile(specPath, Options{
+				Mode:       test.mode,
+				SampleRate: test.sampleRate,
+				OnMismatch: func(mismatch *Mismatch) { mismatches++ },
+			})
+			assert.NoError(t, err)
+			handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set("Set-Cookie", "session=1")
+				w.WriteHeader(test.handlerStatus)
+				_, _ = w.Write([]byte(test.handlerBody))
+			}))
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, test.target, nil))
+
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+			assert.JSONEq(t, test.expectedBody, strings.TrimSpace(recorder.Body.String()))
+			assert.Equal(t, test.expectedMismatches, mismatches)
+			if test.expectedStatus == test.handlerStatus {
+				assert.Equal(t, "session=1", recorder.Header().Get("Set-Cookie"))
+			} else {
+				// the replacing error does not carry the headers of the invalid response
+				assert.Empty(t, recorder.Header().Get("Set-Cookie"))
+			}
+		})
+	}
+}
+
+func Test_MiddlewareDefaultSampleRate(t *testing.T) {
+	openAPI := &oas.OpenAPIObject{}
+	handler := NewMiddleware(openAPI, Options{Mode: ModeSampled})(http.NotFoundHandler())
+
+	assert.Equal(t, DefaultSampleRate, handler.(*middleware).options.SampleRate)
+}
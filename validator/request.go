@@ -0,0 +1,198 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+)
+
+const maxMultipartMemory = 32 << 20
+
+type Validator struct {
+	OpenAPI *oas.OpenAPIObject
+}
+
+func New(openAPI *oas.OpenAPIObject) *Validator {
+	return &Validator{OpenAPI: openAPI}
+}
+
+// ValidateRequest checks the parameters and the body of a request against the matched operation.
+// The request body is restored so that it can be read again by the caller.
+func (v *Validator) ValidateRequest(r *http.Request, route *Route) error {
+	var errs ValidationErrors
+	for i := range route.Operation.Parameters {
+		parameter := v.OpenAPI.ResolveParameter(&route.Operation.Parameters[i])
+		errs = append(errs, v.validateParameter(r, route, parameter)...)
+	}
+	errs = append(errs, v.validateRequestBody(r, route.Operation.RequestBody)...)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func (v *Validator) validateParameter(r *http.Request, route *Route, parameter *oas.ParameterObject) ValidationErrors {
+	raw, present := parameterValue(r, route, parameter)
+	if !present {
+		if parameter.Required {
+			return ValidationErrors{{In: parameter.In, Field: parameter.Name, Message: "is required"}}
+		}
+		return nil
+	}
+	value, err := ParseParameterValue(v.OpenAPI, parameter.Schema, raw)
+	if err != nil {
+		return ValidationErrors{{In: parameter.In, Field: parameter.Name, Message: err.Error()}}
+	}
+	return ValidateValue(v.OpenAPI, parameter.Schema, value, parameter.In, parameter.Name)
+}
+
+func parameterValue(r *http.Request, route *Route, parameter *oas.ParameterObject) (string, bool) {
+	switch parameter.In {
+	case "path":
+		value, ok := route.PathParams[parameter.Name]
+		return value, ok
+	case "query":
+		values, ok := r.URL.Query()[parameter.Name]
+		if !ok || len(values) == 0 {
+			return "", false
+		}
+		return strings.Join(values, ","), true
+	case "header":
+		values := r.Header.Values(parameter.Name)
+		if len(values) == 0 {
+			return "", false
+		}
+		return strings.Join(values, ","), true
+	case "cookie":
+		cookie, err := r.Cookie(parameter.Name)
+		if err != nil {
+			return "", false
+		}
+		return cookie.Value, true
+	}
+	return "", false
+}
+
+// ParseParameterValue converts the textual value of a parameter into the JSON value described by
+// its schema, so it can be checked with ValidateValue
+func ParseParameterValue(openAPI *oas.OpenAPIObject, schema *oas.SchemaObject, raw string) (interface{}, error) {
+	schema = openAPI.ResolveSchema(schema)
+	if schema == nil {
+		return raw, nil
+	}
+	switch schema.Type {
+	case "integer", "number":
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected %s but got %q", schema.Type, raw)
+		}
+		return number, nil
+	case "boolean":
+		boolean, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("expected boolean but got %q", raw)
+		}
+		return boolean, nil
+	case "array":
+		items := []interface{}{}
+		if raw == "" {
+			return items, nil
+		}
+		for _, rawItem := range strings.Split(raw, ",") {
+			item, err := ParseParameterValue(openAPI, schema.Items, rawItem)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case "object":
+		object := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(raw), &object); err != nil {
+			return nil, fmt.Errorf("expected json object but got %q", raw)
+		}
+		return object, nil
+	}
+	return raw, nil
+}
+
+func (v *Validator) validateRequestBody(r *http.Request, requestBody *oas.RequestBodyObject) ValidationErrors {
+	if requestBody == nil {
+		return nil
+	}
+	body, err := readBody(r)
+	if err != nil {
+		return ValidationErrors{{In: "body", Message: fmt.Sprintf("can not read body: %s", err)}}
+	}
+	if len(body) == 0 {
+		if requestBody.Required {
+			return ValidationErrors{{In: "body", Message: "is required"}}
+		}
+		return nil
+	}
+
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if contentType == "" {
+		contentType = oas.ContentTypeJson
+	}
+	mediaType, ok := requestBody.Content[contentType]
+	if !ok {
+		return ValidationErrors{{In: "body", Message: fmt.Sprintf("unsupported content type %s", contentType)}}
+	}
+
+	switch contentType {
+	case oas.ContentTypeJson:
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err != nil {
+			return ValidationErrors{{In: "body", Message: fmt.Sprintf("invalid json: %s", err)}}
+		}
+		return ValidateValue(v.OpenAPI, &mediaType.Schema, value, "body", "")
+	case oas.ContentTypeForm:
+		return v.validateMultipartForm(r, &mediaType.Schema)
+	}
+	return nil
+}
+
+func (v *Validator) validateMultipartForm(r *http.Request, schema *oas.SchemaObject) ValidationErrors {
+	if err := r.ParseMultipartForm(maxMultipartMemory); err != nil {
+		return ValidationErrors{{In: "body", Message: fmt.Sprintf("invalid multipart form: %s", err)}}
+	}
+	if schema.Properties == nil {
+		return nil
+	}
+	var errs ValidationErrors
+	for _, name := range schema.Properties.Keys() {
+		propertySchema, _ := schema.PropertySchema(name)
+		if propertySchema == nil || propertySchema.Format == "binary" {
+			continue
+		}
+		values, ok := r.MultipartForm.Value[name]
+		if !ok {
+			continue
+		}
+		value, err := ParseParameterValue(v.OpenAPI, propertySchema, strings.Join(values, ","))
+		if err != nil {
+			errs = append(errs, &ValidationError{In: "body", Field: name, Message: err.Error()})
+			continue
+		}
+		errs = append(errs, ValidateValue(v.OpenAPI, propertySchema, value, "body", name)...)
+	}
+	return errs
+}
+
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, err
+}
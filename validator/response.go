@@ -0,0 +1,60 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+)
+
+// ValidateResponse checks that the status code is documented for the operation, by itself, by its
+// range, eg. 2XX, or by the default response, and that a json body matches the schema of that response
+func (v *Validator) ValidateResponse(route *Route, statusCode int, header http.Header, body []byte) error {
+	response, ok := route.Operation.Responses[strconv.Itoa(statusCode)]
+	if !ok {
+		response, ok = route.Operation.Responses[fmt.Sprintf("%dXX", statusCode/100)]
+	}
+	if !ok {
+		response, ok = route.Operation.Responses["default"]
+	}
+	if !ok {
+		return ValidationErrors{{In: "response", Message: fmt.Sprintf("status %d is not documented", statusCode)}}
+	}
+	if len(body) == 0 || len(response.Content) == 0 {
+		return nil
+	}
+
+	contentType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	if contentType != oas.ContentTypeJson {
+		return nil
+	}
+	mediaType, ok := response.Content[oas.ContentTypeJson]
+	if !ok {
+		return ValidationErrors{{In: "response", Message: fmt.Sprintf("content type %s is not documented for status %d", contentType, statusCode)}}
+	}
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return ValidationErrors{{In: "response", Message: fmt.Sprintf("invalid json: %s", err)}}
+	}
+	if errs := ValidateValue(v.OpenAPI, &mediaType.Schema, value, "response", ""); len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// WriteErrors writes a json body listing the validation errors
+func WriteErrors(w http.ResponseWriter, status int, err error) {
+	messages := []string{err.Error()}
+	if errs, ok := err.(ValidationErrors); ok {
+		messages = messages[:0]
+		for _, e := range errs {
+			messages = append(messages, e.Error())
+		}
+	}
+	w.Header().Set("Content-Type", oas.ContentTypeJson)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string][]string{"errors": messages})
+}
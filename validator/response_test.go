@@ -0,0 +1,48 @@
+package validator
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/iancoleman/orderedmap"
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValidateResponse(t *testing.T) {
+	errorProperties := orderedmap.New()
+	errorProperties.Set("code", &oas.SchemaObject{Type: "string"})
+	errorContent := map[string]*oas.MediaTypeObject{
+		oas.ContentTypeJson: {Schema: oas.SchemaObject{Type: "object", Properties: errorProperties, Required: []string{"code"}}},
+	}
+	route := &Route{Operation: &oas.OperationObject{Responses: oas.ResponsesObject{
+		"200": {Description: "User", Content: map[string]*oas.MediaTypeObject{
+			oas.ContentTypeJson: {Schema: oas.SchemaObject{Type: "object"}},
+		}},
+		"4XX": {Description: "Client error", Content: errorContent},
+	}}}
+	tests := []struct {
+		name          string
+		statusCode    int
+		body          string
+		expectedError string
+	}{
+		{name: "Should validate the documented status", statusCode: http.StatusOK, body: `{}`},
+		{name: "Should validate a status by its range", statusCode: http.StatusNotFound, body: `{"code":"missing"}`},
+		{name: "Should validate the body of a range", statusCode: http.StatusConflict, body: `{}`, expectedError: "response code: is required"},
+		{name: "Should reject a status out of the documented ranges", statusCode: http.StatusBadGateway, body: `{}`, expectedError: "response: status 502 is not documented"},
+	}
+	validator := New(&oas.OpenAPIObject{})
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			header := http.Header{"Content-Type": []string{oas.ContentTypeJson}}
+			err := validator.ValidateResponse(route, test.statusCode, header, []byte(test.body))
+
+			if test.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedError)
+			}
+		})
+	}
+}
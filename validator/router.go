@@ -0,0 +1,120 @@
+package validator
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+)
+
+var (
+	ErrPathNotFound     = errors.New("path not found")
+	ErrMethodNotAllowed = errors.New("method not allowed")
+)
+
+// Route is an operation matched against an incoming request
+type Route struct {
+	Path       string
+	Method     string
+	PathItem   *oas.PathItemObject
+	Operation  *oas.OperationObject
+	PathParams map[string]string
+}
+
+type Router struct {
+	routes []pathTemplate
+}
+
+type pathTemplate struct {
+	path     string
+	segments []string
+	params   int
+	item     *oas.PathItemObject
+}
+
+// NewRouter indexes the path templates of the spec. Static segments win over template parameters
+// when several templates match the same request path.
+func NewRouter(openAPI *oas.OpenAPIObject) *Router {
+	router := &Router{}
+	for path, item := range openAPI.Paths {
+		if item == nil {
+			continue
+		}
+		segments := splitPath(path)
+		params := 0
+		for _, segment := range segments {
+			if isTemplateSegment(segment) {
+				params++
+			}
+		}
+		router.routes = append(router.routes, pathTemplate{path: path, segments: segments, params: params, item: item})
+	}
+	sort.Slice(router.routes, func(i, j int) bool {
+		if router.routes[i].params != router.routes[j].params {
+			return router.routes[i].params < router.routes[j].params
+		}
+		return router.routes[i].path < router.routes[j].path
+	})
+	return router
+}
+
+// FindRoute returns the documented operation for the method and request path
+func (r *Router) FindRoute(method, path string) (*Route, error) {
+	segments := splitPath(path)
+	pathFound := false
+	for _, template := range r.routes {
+		pathParams, ok := template.match(segments)
+		if !ok {
+			continue
+		}
+		pathFound = true
+		operation := template.item.Operation(method)
+		if operation == nil {
+			continue
+		}
+		return &Route{
+			Path:       template.path,
+			Method:     strings.ToUpper(method),
+			PathItem:   template.item,
+			Operation:  operation,
+			PathParams: pathParams,
+		}, nil
+	}
+	if pathFound {
+		return nil, ErrMethodNotAllowed
+	}
+	return nil, ErrPathNotFound
+}
+
+func (t pathTemplate) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(t.segments) {
+		return nil, false
+	}
+	pathParams := map[string]string{}
+	for i, segment := range t.segments {
+		if isTemplateSegment(segment) {
+			if segments[i] == "" {
+				return nil, false
+			}
+			pathParams[segment[1:len(segment)-1]] = segments[i]
+			continue
+		}
+		if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return pathParams, true
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return []string{}
+	}
+	return strings.Split(path, "/")
+}
+
+func isTemplateSegment(segment string) bool {
+	return len(segment) > 2 && strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
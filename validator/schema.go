@@ -0,0 +1,261 @@
+package validator
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+)
+
+// ValidationError describes a single mismatch between a value and the spec
+type ValidationError struct {
+	In      string // path, query, header, cookie, body or response
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%s: %s", e.In, e.Message)
+	}
+	return fmt.Sprintf("%s %s: %s", e.In, e.Field, e.Message)
+}
+
+// ValidationErrors collects every mismatch found while validating a request or response
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ValidateValue checks a decoded JSON value against a schema. Fields are reported as json paths
+// relative to field.
+func ValidateValue(openAPI *oas.OpenAPIObject, schema *oas.SchemaObject, value interface{}, in, field string) ValidationErrors {
+	v := schemaValidator{openAPI: openAPI, in: in, refDepth: map[string]int{}}
+	v.validate(schema, value, field)
+	return v.errs
+}
+
+const maxRefDepth = 32
+
+type schemaValidator struct {
+	openAPI  *oas.OpenAPIObject
+	in       string
+	refDepth map[string]int
+	errs     ValidationErrors
+}
+
+func (v *schemaValidator) fail(field, format string, args ...interface{}) {
+	v.errs = append(v.errs, &ValidationError{In: v.in, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *schemaValidator) validate(schema *oas.SchemaObject, value interface{}, field string) {
+	if schema == nil {
+		return
+	}
+	if schema.Ref != "" {
+		if v.refDepth[schema.Ref] >= maxRefDepth {
+			return
+		}
+		resolved, ok := v.openAPI.SchemaByRef(schema.Ref)
+		if !ok {
+			return
+		}
+		v.refDepth[schema.Ref]++
+		v.validate(resolved, value, field)
+		v.refDepth[schema.Ref]--
+		return
+	}
+	v.validateNot(schema, value, field)
+	if value == nil {
+		if !schema.Nullable && schema.Type != "" && schema.Type != "object" {
+			v.fail(field, "must not be null")
+		}
+		return
+	}
+	if !v.validateType(schema, value, field) {
+		return
+	}
+	v.validateEnum(schema, value, field)
+
+	switch typedValue := value.(type) {
+	case string:
+		v.validateString(schema, typedValue, field)
+	case float64:
+		v.validateNumber(schema, typedValue, field)
+	case []interface{}:
+		v.validateArray(schema, typedValue, field)
+	case map[string]interface{}:
+		v.validateObject(schema, typedValue, field)
+	}
+}
+
+func (v *schemaValidator) validateType(schema *oas.SchemaObject, value interface{}, field string) bool {
+	valid := true
+	switch schema.Type {
+	case "string":
+		_, valid = value.(string)
+	case "boolean":
+		_, valid = value.(bool)
+	case "number":
+		_, valid = value.(float64)
+	case "integer":
+		number, ok := value.(float64)
+		valid = ok && number == math.Trunc(number)
+	case "array":
+		_, valid = value.([]interface{})
+	case "object":
+		_, valid = value.(map[string]interface{})
+	}
+	if !valid {
+		v.fail(field, "expected %s but got %s", schema.Type, jsonTypeOf(value))
+	}
+	return valid
+}
+
+func (v *schemaValidator) validateEnum(schema *oas.SchemaObject, value interface{}, field string) {
+	enum, ok := schema.Enum.([]interface{})
+	if !ok || len(enum) == 0 {
+		return
+	}
+	for _, allowed := range enum {
+		if reflect.DeepEqual(allowed, value) || fmt.Sprint(allowed) == fmt.Sprint(value) {
+			return
+		}
+	}
+	v.fail(field, "value %v is not one of %v", value, enum)
+}
+
+// validateNot fails a value which matches the not schema
+func (v *schemaValidator) validateNot(schema *oas.SchemaObject, value interface{}, field string) {
+	if schema.Not == nil {
+		return
+	}
+	not := schemaValidator{openAPI: v.openAPI, in: v.in, refDepth: v.refDepth}
+	not.validate(schema.Not, value, field)
+	if len(not.errs) == 0 {
+		v.fail(field, "value %v must not match the not schema", value)
+	}
+}
+
+func (v *schemaValidator) validateString(schema *oas.SchemaObject, value, field string) {
+	length := uint(len([]rune(value)))
+	if schema.MinLength != 0 && length < schema.MinLength {
+		v.fail(field, "length must be at least %d", schema.MinLength)
+	}
+	if schema.MaxLength != 0 && length > schema.MaxLength {
+		v.fail(field, "length must be at most %d", schema.MaxLength)
+	}
+	if schema.Pattern != "" {
+		re, err := regexp.Compile(schema.Pattern)
+		if err == nil && !re.MatchString(value) {
+			v.fail(field, "does not match pattern %s", schema.Pattern)
+		}
+	}
+}
+
+func (v *schemaValidator) validateNumber(schema *oas.SchemaObject, value float64, field string) {
+	if schema.Minimum != 0 || schema.ExclusiveMinimum {
+		if schema.ExclusiveMinimum && value <= schema.Minimum {
+			v.fail(field, "must be greater than %v", schema.Minimum)
+		} else if value < schema.Minimum {
+			v.fail(field, "must be greater than or equal to %v", schema.Minimum)
+		}
+	}
+	if schema.Maximum != 0 || schema.ExclusiveMaximum {
+		if schema.ExclusiveMaximum && value >= schema.Maximum {
+			v.fail(field, "must be less than %v", schema.Maximum)
+		} else if value > schema.Maximum {
+			v.fail(field, "must be less than or equal to %v", schema.Maximum)
+		}
+	}
+	if schema.MultipleOf > 0 {
+		// the quotient of floats is rounded, eg. 0.3 / 0.1 is 2.9999999999999996
+		quotient := value / schema.MultipleOf
+		if math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			v.fail(field, "must be a multiple of %v", schema.MultipleOf)
+		}
+	}
+}
+
+func (v *schemaValidator) validateArray(schema *oas.SchemaObject, value []interface{}, field string) {
+	if schema.MinItems != 0 && uint(len(value)) < schema.MinItems {
+		v.fail(field, "must contain at least %d items", schema.MinItems)
+	}
+	if schema.MaxItems != 0 && uint(len(value)) > schema.MaxItems {
+		v.fail(field, "must contain at most %d items", schema.MaxItems)
+	}
+	if schema.UniqueItems {
+		for i := range value {
+			for j := i + 1; j < len(value); j++ {
+				if reflect.DeepEqual(value[i], value[j]) {
+					v.fail(field, "items %d and %d are equal", i, j)
+				}
+			}
+		}
+	}
+	for i, item := range value {
+		v.validate(schema.Items, item, fmt.Sprintf("%s[%d]", field, i))
+	}
+}
+
+func (v *schemaValidator) validateObject(schema *oas.SchemaObject, value map[string]interface{}, field string) {
+	if schema.MinProperties != 0 && uint(len(value)) < schema.MinProperties {
+		v.fail(field, "must contain at least %d properties", schema.MinProperties)
+	}
+	if schema.MaxProperties != 0 && uint(len(value)) > schema.MaxProperties {
+		v.fail(field, "must contain at most %d properties", schema.MaxProperties)
+	}
+	for _, name := range schema.Required {
+		if _, ok := value[name]; !ok {
+			v.fail(joinField(field, name), "is required")
+		}
+	}
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if propertySchema, ok := schema.PropertySchema(name); ok {
+			v.validate(propertySchema, value[name], joinField(field, name))
+		} else if !schema.AdditionalProperties.IsAllowed() {
+			v.fail(joinField(field, name), "is not allowed")
+		} else if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			v.validate(schema.AdditionalProperties.Schema, value[name], joinField(field, name))
+		}
+	}
+}
+
+func joinField(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func jsonTypeOf(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,51 @@
+package validator
+
+import (
+	"testing"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValidateValue(t *testing.T) {
+	tests := []struct {
+		name           string
+		schema         *oas.SchemaObject
+		value          interface{}
+		expectedErrors []string
+	}{
+		{
+			name:   "Should accept a multiple",
+			schema: &oas.SchemaObject{Type: "number", MultipleOf: 0.01},
+			value:  12.3,
+		},
+		{
+			name:           "Should reject a value which is not a multiple",
+			schema:         &oas.SchemaObject{Type: "integer", MultipleOf: 5},
+			value:          float64(12),
+			expectedErrors: []string{"body price: must be a multiple of 5"},
+		},
+		{
+			name:   "Should accept a value not matching the not schema",
+			schema: &oas.SchemaObject{Type: "string", Not: &oas.SchemaObject{Enum: []interface{}{"deleted", "archived"}}},
+			value:  "active",
+		},
+		{
+			name:           "Should reject a value matching the not schema",
+			schema:         &oas.SchemaObject{Type: "string", Not: &oas.SchemaObject{Enum: []interface{}{"deleted", "archived"}}},
+			value:          "deleted",
+			expectedErrors: []string{"body price: value deleted must not match the not schema"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := ValidateValue(&oas.OpenAPIObject{}, test.schema, test.value, "body", "price")
+
+			var messages []string
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+			assert.Equal(t, test.expectedErrors, messages)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	log "github.com/sirupsen/logrus"
+)
+
+const goSourceHelpers = `
+// JSONHandler serves the document as json
+func JSONHandler() http.Handler {
+	return specHandler("application/json", SpecJSON)
+}
+
+// YAMLHandler serves the document as yaml
+func YAMLHandler() http.Handler {
+	return specHandler("application/yaml", SpecYAML)
+}
+
+// Handler serves the document as yaml when the path ends with .yaml or .yml or the request
+// accepts yaml, as json otherwise
+func Handler() http.Handler {
+	jsonHandler, yamlHandler := JSONHandler(), YAMLHandler()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".yaml") || strings.HasSuffix(r.URL.Path, ".yml") ||
+			strings.Contains(r.Header.Get("Accept"), "yaml") {
+			yamlHandler.ServeHTTP(w, r)
+			return
+		}
+		jsonHandler.ServeHTTP(w, r)
+	})
+}
+
+func specHandler(contentType string, spec []byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Write(spec)
+	})
+}
+`
+
+const goSourceObject = `
+// OpenAPI returns the parsed document
+func OpenAPI() (oas.OpenAPIObject, error) {
+	var openAPI oas.OpenAPIObject
+	err := json.Unmarshal(SpecJSON, &openAPI)
+	return openAPI, err
+}
+`
+
+// WriteGo writes a go file embedding the spec as json and yaml along with http handlers serving it,
+// withObject adds a function returning the parsed OpenAPIObject
+func (w *fileWriter) WriteGo(openApiObject oas.OpenAPIObject, path, packageName string, withObject bool) error {
+	if packageName == "" {
+		packageName = directoryPackageName(path)
+	}
+	if !token.IsIdentifier(packageName) {
+		return fmt.Errorf("Invalid package name %q for %s, set it with --go-package", packageName, path)
+	}
+	log.Infof("Writing open api object go source to %s ...", path)
+
+	specJSON, err := json.MarshalIndent(openApiObject, "", "  ")
+	if err != nil {
+		return err
+	}
+	specYAML, err := jsonToOrderedYAML(specJSON)
+	if err != nil {
+		return err
+	}
+
+	var source bytes.Buffer
+	source.WriteString("// Code generated by go-swagger3. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&source, "package %s\n\n", packageName)
+	if withObject {
+		source.WriteString("import (\n\"encoding/json\"\n\"net/http\"\n\"strings\"\n\n")
+		fmt.Fprintf(&source, "oas %q\n)\n\n", "github.com/parvez3019/go-swagger3/openApi3Schema")
+	} else {
+		source.WriteString("import (\n\"net/http\"\n\"strings\"\n)\n\n")
+	}
+	fmt.Fprintf(&source, "// SpecJSON is the open api document as json\nvar SpecJSON = []byte(%s)\n\n", goStringLiteral(specJSON))
+	fmt.Fprintf(&source, "// SpecYAML is the open api document as yaml\nvar SpecYAML = []byte(%s)\n", goStringLiteral(specYAML))
+	source.WriteString(goSourceHelpers)
+	if withObject {
+		source.WriteString(goSourceObject)
+	}
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return fmt.Errorf("Can not format the go source of the spec: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("Can not create the directory %s: %v", filepath.Dir(path), err)
+	}
+	if err := ioutil.WriteFile(path, formatted, 0644); err != nil {
+		return fmt.Errorf("Can not create the file %s: %v", path, err)
+	}
+	return nil
+}
+
+// directoryPackageName makes the name of the directory of a file a package name, eg. goswagger3 for
+// go-swagger3/spec_gen.go, the relative paths are resolved first so that spec_gen.go gets the name of
+// the working directory
+func directoryPackageName(path string) string {
+	if absolute, err := filepath.Abs(path); err == nil {
+		path = absolute
+	}
+	var name strings.Builder
+	for _, r := range strings.ToLower(filepath.Base(filepath.Dir(path))) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			name.WriteRune(r)
+		}
+	}
+	return name.String()
+}
+
+// goStringLiteral is a raw string literal, unless the content holds a backquote
+func goStringLiteral(content []byte) string {
+	if strings.Contains(string(content), "`") || strings.Contains(string(content), "\r") {
+		return strconv.Quote(string(content))
+	}
+	return "`" + string(content) + "`"
+}
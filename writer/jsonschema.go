@@ -0,0 +1,258 @@
+package writer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/iancoleman/orderedmap"
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/parser/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	JSONSchemaDraft202012 = "2020-12"
+	JSONSchemaDraft07     = "draft-07"
+
+	// JSONSchemaRefsFiles references the other schemas by their file, eg. "User.json"
+	JSONSchemaRefsFiles = "files"
+	// JSONSchemaRefsDefs copies the referenced schemas into the $defs (definitions for draft-07) of every file
+	JSONSchemaRefsDefs = "defs"
+)
+
+var jsonSchemaURLs = map[string]string{
+	JSONSchemaDraft202012: "https://json-schema.org/draft/2020-12/schema",
+	JSONSchemaDraft07:     "http://json-schema.org/draft-07/schema#",
+}
+
+// jsonSchemaConverter turns the schemas of the spec into standalone json schemas
+type jsonSchemaConverter struct {
+	openApiObject oas.OpenAPIObject
+	refs          string
+	defsKey       string
+	// files is the file of every component schema
+	files map[string]string
+}
+
+// WriteJSONSchemas writes every component schema as a standalone json schema file into dir. The
+// references to the other components point to their file or to a copy in $defs, and the open api
+// keywords are converted: nullable adds "null" to the types, example becomes examples and the
+// boolean exclusiveMinimum and exclusiveMaximum take the value of minimum and maximum.
+func (w *fileWriter) WriteJSONSchemas(openApiObject oas.OpenAPIObject, dir, draft, refs string) error {
+	schemaURL, ok := jsonSchemaURLs[draft]
+	if !ok {
+		return fmt.Errorf("unknown json schema draft %s, expected %s or %s", draft, JSONSchemaDraft202012, JSONSchemaDraft07)
+	}
+	if refs != JSONSchemaRefsFiles && refs != JSONSchemaRefsDefs {
+		return fmt.Errorf("unknown json schema refs %s, expected %s or %s", refs, JSONSchemaRefsFiles, JSONSchemaRefsDefs)
+	}
+	log.Infof("Writing json schemas to %s ...", dir)
+
+	c := jsonSchemaConverter{openApiObject: openApiObject, refs: refs, defsKey: "$defs", files: map[string]string{}}
+	if draft == JSONSchemaDraft07 {
+		c.defsKey = "definitions"
+	}
+	used := map[string]bool{}
+	for _, name := range utils.SortedKeys(openApiObject.Components.Schemas) {
+		file := EscapeFileName(name)
+		for i := 2; used[strings.ToLower(file)]; i++ {
+			file = fmt.Sprintf("%s_%d", EscapeFileName(name), i)
+		}
+		used[strings.ToLower(file)] = true
+		c.files[name] = file + ".json"
+	}
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("Can not create the directory %s: %v", dir, err)
+	}
+	for _, name := range utils.SortedKeys(openApiObject.Components.Schemas) {
+		document := orderedmap.New()
+		document.Set("$schema", schemaURL)
+		document.Set("$id", c.files[name])
+		if schema := openApiObject.Components.Schemas[name]; schema == nil || schema.Title == "" {
+			document.Set("title", name)
+		}
+		referenced := map[string]bool{}
+		converted, err := c.convertComponent(name, referenced)
+		if err != nil {
+			return err
+		}
+		for _, key := range converted.Keys() {
+			value, _ := converted.Get(key)
+			document.Set(key, value)
+		}
+		if refs == JSONSchemaRefsDefs && len(referenced) != 0 {
+			defs := orderedmap.New()
+			// the schemas the copies reference are copied too
+			for added := true; added; {
+				added = false
+				for _, ref := range utils.SortedKeys(referenced) {
+					if _, ok := defs.Get(ref); ok {
+						continue
+					}
+					def, err := c.convertComponent(ref, referenced)
+					if err != nil {
+						return err
+					}
+					defs.Set(ref, def)
+					added = true
+				}
+			}
+			defs.SortKeys(sort.Strings)
+			document.Set(c.defsKey, defs)
+		}
+
+		output, err := json.MarshalIndent(document, "", "  ")
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, c.files[name])
+		if err := ioutil.WriteFile(path, output, 0644); err != nil {
+			return fmt.Errorf("Can not create the file %s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+func (c jsonSchemaConverter) convertComponent(name string, referenced map[string]bool) (*orderedmap.OrderedMap, error) {
+	b, err := json.Marshal(c.openApiObject.Components.Schemas[name])
+	if err != nil {
+		return nil, err
+	}
+	schema := orderedmap.New()
+	if err := json.Unmarshal(b, schema); err != nil {
+		return nil, err
+	}
+	return c.convert(schema, referenced), nil
+}
+
+// convert converts a schema and its sub schemas, the names of the referenced components are added to referenced
+func (c jsonSchemaConverter) convert(schema *orderedmap.OrderedMap, referenced map[string]bool) *orderedmap.OrderedMap {
+	if ref, ok := schema.Get("$ref"); ok {
+		if name := strings.TrimPrefix(fmt.Sprint(ref), oas.SchemaRefPrefix); name != fmt.Sprint(ref) {
+			referenced[name] = true
+			if c.refs == JSONSchemaRefsDefs {
+				schema.Set("$ref", "#/"+c.defsKey+"/"+name)
+			} else if file, ok := c.files[name]; ok {
+				schema.Set("$ref", file)
+			}
+		}
+	}
+	if properties, ok := asObject(schema.Get("properties")); ok {
+		for _, key := range properties.Keys() {
+			if property, ok := asObject(properties.Get(key)); ok {
+				properties.Set(key, c.convert(property, referenced))
+			}
+		}
+		schema.Set("properties", properties)
+	}
+	for _, key := range []string{"items", "additionalProperties", "not"} {
+		if subSchema, ok := asObject(schema.Get(key)); ok {
+			schema.Set(key, c.convert(subSchema, referenced))
+		}
+	}
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		if list, ok := schema.Get(key); ok {
+			if subSchemas, ok := list.([]interface{}); ok {
+				for i := range subSchemas {
+					if subSchema, ok := asObject(subSchemas[i], true); ok {
+						subSchemas[i] = c.convert(subSchema, referenced)
+					}
+				}
+			}
+		}
+	}
+
+	// OpenAPI 3.0 has no const, the parser documents it as an enum of one value
+	if enum, ok := schema.Get("enum"); ok {
+		if values, ok := enum.([]interface{}); ok && len(values) == 1 {
+			schema.Delete("enum")
+			schema.Set("const", values[0])
+		}
+	}
+	if example, ok := schema.Get("example"); ok {
+		schema.Delete("example")
+		schema.Set("examples", []interface{}{example})
+	}
+	for _, bound := range []string{"minimum", "maximum"} {
+		exclusive := "exclusive" + strings.Title(bound)
+		if isExclusive, ok := schema.Get(exclusive); ok {
+			schema.Delete(exclusive)
+			if value, ok := schema.Get(bound); ok && isExclusive == true {
+				schema.Delete(bound)
+				schema.Set(exclusive, value)
+			}
+		}
+	}
+	if nullable, ok := schema.Get("nullable"); ok {
+		schema.Delete("nullable")
+		if nullable == true {
+			makeNullable(schema)
+		}
+	}
+	if keyType, ok := schema.Get("x-key-type"); ok {
+		schema.Delete("x-key-type")
+		if pattern, ok := keyPatterns[fmt.Sprint(keyType)]; ok {
+			propertyNames := orderedmap.New()
+			propertyNames.Set("pattern", pattern)
+			schema.Set("propertyNames", propertyNames)
+		}
+	}
+	return schema
+}
+
+// keyPatterns are the patterns of the keys of the maps of integers, by go type
+var keyPatterns = map[string]string{
+	"int":    "^-?[0-9]+$",
+	"int8":   "^-?[0-9]+$",
+	"int16":  "^-?[0-9]+$",
+	"int32":  "^-?[0-9]+$",
+	"int64":  "^-?[0-9]+$",
+	"uint":   "^[0-9]+$",
+	"uint8":  "^[0-9]+$",
+	"uint16": "^[0-9]+$",
+	"uint32": "^[0-9]+$",
+	"uint64": "^[0-9]+$",
+}
+
+// makeNullable adds null to the type and to the enum of a schema, a reference becomes a choice of the reference and null
+func makeNullable(schema *orderedmap.OrderedMap) {
+	if ref, ok := schema.Get("$ref"); ok {
+		schema.Delete("$ref")
+		refSchema := orderedmap.New()
+		refSchema.Set("$ref", ref)
+		nullSchema := orderedmap.New()
+		nullSchema.Set("type", "null")
+		schema.Set("anyOf", []interface{}{refSchema, nullSchema})
+		return
+	}
+	if schemaType, ok := schema.Get("type"); ok {
+		if typeName, ok := schemaType.(string); ok {
+			schema.Set("type", []interface{}{typeName, "null"})
+		}
+	}
+	if enum, ok := schema.Get("enum"); ok {
+		if values, ok := enum.([]interface{}); ok {
+			schema.Set("enum", append(values, nil))
+		}
+	}
+}
+
+// asObject returns the json object of a value decoded by orderedmap, which holds nested objects by value
+func asObject(value interface{}, ok bool) (*orderedmap.OrderedMap, bool) {
+	if !ok {
+		return nil, false
+	}
+	switch object := value.(type) {
+	case orderedmap.OrderedMap:
+		return &object, true
+	case *orderedmap.OrderedMap:
+		return object, true
+	}
+	return nil, false
+}
@@ -0,0 +1,239 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	oas "github.com/parvez3019/go-swagger3/openApi3Schema"
+	"github.com/parvez3019/go-swagger3/parser/utils"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+// TreeRootFile is the file of a tree written by WriteTree that holds the info, the servers and the references to the other files
+const TreeRootFile = "openapi.yaml"
+
+var componentRefRegexp = regexp.MustCompile(`"\$ref": "#/components/([^/"]+)/([^"]+)"`)
+
+type fileRef struct {
+	Ref string `json:"$ref"`
+}
+
+type treeRoot struct {
+	Version    string                `json:"openapi"`
+	Info       oas.InfoObject        `json:"info"`
+	Servers    []oas.ServerObject    `json:"servers,omitempty"`
+	Paths      map[string]fileRef    `json:"paths"`
+	Components treeComponents        `json:"components,omitempty"`
+	Security   []map[string][]string `json:"security,omitempty"`
+}
+
+type treeComponents struct {
+	Schemas         map[string]fileRef `json:"schemas,omitempty"`
+	SecuritySchemes map[string]fileRef `json:"securitySchemes,omitempty"`
+	Parameters      map[string]fileRef `json:"parameters,omitempty"`
+}
+
+// treeFiles is the file of every path and component, relative to the root directory
+type treeFiles struct {
+	paths      map[string]string
+	components map[string]map[string]string
+}
+
+// WriteTree writes the spec as a tree of yaml files under dir: the root openapi.yaml gets the
+// info, the servers and the references to a file per path under paths/ and per schema, parameter
+// and security scheme under components/. The references between the files are relative.
+func (w *fileWriter) WriteTree(openApiObject oas.OpenAPIObject, dir string) error {
+	log.Infof("Writing open api object tree to %s ...", dir)
+	files := newTreeFiles(openApiObject)
+
+	root := treeRoot{
+		Version:  openApiObject.Version,
+		Info:     openApiObject.Info,
+		Servers:  openApiObject.Servers,
+		Paths:    map[string]fileRef{},
+		Security: openApiObject.Security,
+	}
+	for path, file := range files.paths {
+		root.Paths[path] = fileRef{Ref: file}
+	}
+	for kind, names := range files.components {
+		refs := map[string]fileRef{}
+		for name, file := range names {
+			refs[name] = fileRef{Ref: file}
+		}
+		switch kind {
+		case "schemas":
+			root.Components.Schemas = refs
+		case "securitySchemes":
+			root.Components.SecuritySchemes = refs
+		case "parameters":
+			root.Components.Parameters = refs
+		}
+	}
+	if err := files.write(dir, TreeRootFile, root); err != nil {
+		return err
+	}
+
+	for path, item := range openApiObject.Paths {
+		if err := files.write(dir, files.paths[path], item); err != nil {
+			return err
+		}
+	}
+	for name, schema := range openApiObject.Components.Schemas {
+		if err := files.write(dir, files.components["schemas"][name], schema); err != nil {
+			return err
+		}
+	}
+	for name, scheme := range openApiObject.Components.SecuritySchemes {
+		if err := files.write(dir, files.components["securitySchemes"][name], scheme); err != nil {
+			return err
+		}
+	}
+	for name, parameter := range openApiObject.Components.Parameters {
+		if err := files.write(dir, files.components["parameters"][name], parameter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTreeFiles(openApiObject oas.OpenAPIObject) treeFiles {
+	files := treeFiles{paths: map[string]string{}, components: map[string]map[string]string{}}
+	used := map[string]bool{}
+	unusedFile := func(dir, name string) string {
+		file := dir + "/" + name + ".yaml"
+		for i := 2; used[strings.ToLower(file)]; i++ {
+			file = dir + "/" + name + "_" + strconv.Itoa(i) + ".yaml"
+		}
+		used[strings.ToLower(file)] = true
+		return file
+	}
+
+	for _, path := range utils.SortedKeys(openApiObject.Paths) {
+		files.paths[path] = unusedFile("paths", EscapeFileName(strings.TrimPrefix(path, "/")))
+	}
+	addComponents := func(kind string, names []string) {
+		if len(names) == 0 {
+			return
+		}
+		files.components[kind] = map[string]string{}
+		for _, name := range names {
+			files.components[kind][name] = unusedFile("components/"+kind, EscapeFileName(name))
+		}
+	}
+	addComponents("schemas", utils.SortedKeys(openApiObject.Components.Schemas))
+	addComponents("securitySchemes", utils.SortedKeys(openApiObject.Components.SecuritySchemes))
+	addComponents("parameters", utils.SortedKeys(openApiObject.Components.Parameters))
+	return files
+}
+
+// write writes a part of the spec as yaml, with its component references relative to the file
+func (f treeFiles) write(dir, file string, value interface{}) error {
+	output, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	output = componentRefRegexp.ReplaceAllFunc(output, func(ref []byte) []byte {
+		match := componentRefRegexp.FindSubmatch(ref)
+		target, ok := f.components[string(match[1])][string(match[2])]
+		if !ok {
+			return ref
+		}
+		relative, err := filepath.Rel(filepath.Dir(filepath.FromSlash(file)), filepath.FromSlash(target))
+		if err != nil {
+			return ref
+		}
+		return []byte(fmt.Sprintf(`"$ref": %q`, filepath.ToSlash(relative)))
+	})
+	output, err = jsonToOrderedYAML(output)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, filepath.FromSlash(file))
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("Can not create the directory %s: %v", filepath.Dir(path), err)
+	}
+	if err := ioutil.WriteFile(path, output, 0644); err != nil {
+		return fmt.Errorf("Can not create the file %s: %v", path, err)
+	}
+	return nil
+}
+
+// jsonToOrderedYAML converts json to yaml keeping the order of the object keys
+func jsonToOrderedYAML(content []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	value, err := decodeOrdered(decoder)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(value)
+}
+
+func decodeOrdered(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := yaml.MapSlice{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, yaml.MapItem{Key: key, Value: value})
+		}
+		_, err = decoder.Token()
+		return object, err
+	case json.Delim('['):
+		array := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err = decoder.Token()
+		return array, err
+	}
+	if number, ok := token.(json.Number); ok {
+		if i, err := number.Int64(); err == nil {
+			return i, nil
+		}
+		return number.Float64()
+	}
+	return token, nil
+}
+
+// EscapeFileName turns a path or a component name into a file name, eg. "users/{id}" -> "users_{id}"
+func EscapeFileName(name string) string {
+	escaped := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r == '.' || r == '-' || r == '{' || r == '}':
+			return r
+		}
+		return '_'
+	}, name)
+	if escaped == "" {
+		return "_root"
+	}
+	return escaped
+}
@@ -21,7 +21,8 @@ func NewFileWriter() *fileWriter {
 	return &fileWriter{}
 }
 
-func filterSchemaWithoutPkg(openApiObject oas.OpenAPIObject) {
+// FilterSchemaWithoutPkg drops the schemas registered without package when the same type is registered with its package
+func FilterSchemaWithoutPkg(openApiObject oas.OpenAPIObject) {
 
 	for key := range openApiObject.Components.Schemas {
 		key_sep := strings.Split(key, ".")
@@ -35,10 +36,7 @@ func filterSchemaWithoutPkg(openApiObject oas.OpenAPIObject) {
 
 }
 
-func (w *fileWriter) Write(openApiObject oas.OpenAPIObject, path string, generateYAML bool, schemaWithoutPkg bool) error {
-	if !schemaWithoutPkg {
-		filterSchemaWithoutPkg(openApiObject)
-	}
+func (w *fileWriter) Write(openApiObject oas.OpenAPIObject, path string, generateYAML bool) error {
 	log.Info("Writing to open api object file ...")
 	fd, err := os.Create(path)
 	if err != nil {